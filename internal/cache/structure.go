@@ -175,6 +175,7 @@ func (sc *StructureCache) hashProject(project models.ProjectConfig) string {
 			Type string `json:"type"`
 			Name string `json:"name"`
 		} `json:"applications"`
+		Packages     []models.PackageType    `json:"packages"`
 		Infrastructure struct {
 			Docker        bool   `json:"docker"`
 			DockerCompose bool   `json:"dockerCompose"`
@@ -189,6 +190,7 @@ func (sc *StructureCache) hashProject(project models.ProjectConfig) string {
 	}{
 		Name:         project.Name,
 		Architecture: project.Architecture,
+		Packages:     project.Packages,
 		Infrastructure: struct {
 			Docker        bool   `json:"docker"`
 			DockerCompose bool   `json:"dockerCompose"`
@@ -80,6 +80,21 @@ func TestStructureCache_DifferentProjects(t *testing.T) {
 	}
 }
 
+func TestStructureCache_PackagesChangeKey(t *testing.T) {
+	cache := NewStructureCache(10, 5*time.Minute)
+	
+	project := models.ProjectConfig{
+		Name:         "project",
+		Architecture: models.ArchitectureTurborepo,
+	}
+	cache.SetStructure(project, 80, 24, "without packages")
+	
+	project.Packages = []models.PackageType{models.PackageUI}
+	if _, found := cache.GetStructure(project, 80, 24); found {
+		t.Error("Expected cache miss after selecting shared packages")
+	}
+}
+
 func TestStructureCache_TTLExpiration(t *testing.T) {
 	cache := NewStructureCache(10, 100*time.Millisecond)
 	
@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+	"teapot/internal/ui"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runAddAppWizard asks for the application to add to an existing project.
+// It is a variable so tests can replace the terminal program.
+var runAddAppWizard = func(project models.ProjectConfig) (models.Application, bool, error) {
+	p := tea.NewProgram(
+		ui.NewAddAppModel(project),
+		tea.WithAltScreen(),
+	)
+	model, err := p.Run()
+	if err != nil {
+		return models.Application{}, false, err
+	}
+	app, ok := model.(ui.AddAppModel).Application()
+	return app, ok, nil
+}
+
+// runAdd adds to the project described by teapot.yml. Only applications are supported.
+func runAdd(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("add", stderr)
+	file := fileFlag(fs)
+	var appFlag string
+	fs.StringVar(&appFlag, "app", "", "add the application `type[:name]` without the wizard, e.g. expo:mobile")
+	positional, ok, code := parseFlags(fs, args, 1)
+	if !ok {
+		return code
+	}
+
+	if len(positional) == 0 {
+		fmt.Fprintln(stderr, "teapot add: missing what to add")
+		fs.Usage()
+		return ExitUsage
+	}
+	if positional[0] != "app" {
+		fmt.Fprintf(stderr, "teapot add: cannot add %q (supported: app)\n", positional[0])
+		return ExitUsage
+	}
+
+	project, err := loadTeapotYAML("add", *file, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot add: %v\n", err)
+		return ExitInvalidConfig
+	}
+	if project.Architecture == models.ArchitectureSingle {
+		fmt.Fprintf(stderr, "teapot add: %s is a single application project and cannot have more applications\n", project.Name)
+		return ExitFailure
+	}
+
+	var app models.Application
+	if appFlag != "" {
+		if app, err = parseAppFlag(appFlag, project.Applications); err != nil {
+			fmt.Fprintf(stderr, "teapot add: %v\n", err)
+			return ExitUsage
+		}
+	} else {
+		var added bool
+		app, added, err = runAddAppWizard(project)
+		if err != nil {
+			fmt.Fprintf(stderr, "Error running Teapot: %v\n", err)
+			return ExitFailure
+		}
+		if !added {
+			fmt.Fprintln(stderr, "teapot add: cancelled, nothing was changed")
+			return ExitFailure
+		}
+	}
+
+	updated := project
+	updated.Applications = append(append([]models.Application{}, project.Applications...), app)
+	if _, err := generator.BuildPlan(updated); err != nil {
+		fmt.Fprintf(stderr, "teapot add: cannot add %s: %v\n", app.Name, err)
+		return ExitUsage
+	}
+
+	// Only the files the application changes are written: its folder and the
+	// workspace, CI, compose and teapot.yml changes, merged like an upgrade does
+	upgrade, err := generator.PlanAddApplication(filepath.Dir(*file), project, app)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot add: %v\n", err)
+		return ExitFailure
+	}
+	printUpgradeReport(stdout, upgrade)
+	if err := upgrade.Apply(); err != nil {
+		fmt.Fprintf(stderr, "teapot add: %v\n", err)
+		return ExitFailure
+	}
+	if !checkConflicts(upgrade, stderr) {
+		return ExitFailure
+	}
+
+	fmt.Fprintf(stdout, "Done. Added %s (%s) to %s\n", app.Name, models.AppTypeNames[app.Type], project.Name)
+	return ExitOK
+}
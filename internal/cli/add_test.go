@@ -0,0 +1,105 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// generateProject generates the teapot.yml test project and returns its teapot.yml path
+func generateProject(t *testing.T) string {
+	t.Helper()
+
+	dir := t.TempDir()
+	output := filepath.Join(dir, "out")
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", writeTeapotYAML(t, dir), "--dir", output}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected generation to succeed, got %d (stderr: %s)", code, stderr.String())
+	}
+	return filepath.Join(output, "teapot.yml")
+}
+
+// stubAddAppWizard replaces the terminal program with one that returns app
+func stubAddAppWizard(t *testing.T, app models.Application, added bool) {
+	t.Helper()
+
+	original := runAddAppWizard
+	runAddAppWizard = func(models.ProjectConfig) (models.Application, bool, error) {
+		return app, added, nil
+	}
+	t.Cleanup(func() { runAddAppWizard = original })
+}
+
+func TestRunAdd_App(t *testing.T) {
+	file := generateProject(t)
+	root := filepath.Dir(file)
+
+	var stdout, stderr bytes.Buffer
+	if code := runAdd([]string{"app", "-f", file, "--app", "expo:mobile"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "apps", "mobile", "package.json")); err != nil {
+		t.Errorf("Expected the new application to be generated: %v", err)
+	}
+	project, err := generator.LoadTeapotYAML(file)
+	if err != nil {
+		t.Fatalf("Expected teapot.yml to load, got error: %v", err)
+	}
+	if len(project.Applications) != 2 || project.Applications[1].Name != "mobile" {
+		t.Errorf("Expected the application to be appended to teapot.yml, got %v", project.Applications)
+	}
+	for _, line := range []string{"  + apps/mobile/package.json (added)", "  ~ teapot.yml (updated)", "Done. Added mobile (Expo) to ci-project"} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, stdout.String())
+		}
+	}
+}
+
+func TestRunAdd_Wizard(t *testing.T) {
+	file := generateProject(t)
+
+	stubAddAppWizard(t, models.Application{ID: "app-nest", Name: "api", Type: models.AppTypeNest, Options: map[string]interface{}{"swagger": true}}, true)
+	var stdout, stderr bytes.Buffer
+	if code := runAdd([]string{"app", "-f", file}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(filepath.Dir(file), "apps", "api", "package.json")); err != nil {
+		t.Errorf("Expected the application chosen in the wizard to be generated: %v", err)
+	}
+
+	stubAddAppWizard(t, models.Application{}, false)
+	if code := runAdd([]string{"app", "-f", file}, &stdout, &stderr); code != ExitFailure {
+		t.Errorf("Expected a cancelled wizard to exit with %d, got %d", ExitFailure, code)
+	}
+}
+
+func TestRunAdd_Errors(t *testing.T) {
+	file := generateProject(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "missing kind", args: nil, want: ExitUsage},
+		{name: "unknown kind", args: []string{"package"}, want: ExitUsage},
+		{name: "invalid app", args: []string{"app", "-f", file, "--app", "svelte"}, want: ExitUsage},
+		{name: "duplicate name", args: []string{"app", "-f", file, "--app", "next:web"}, want: ExitUsage},
+		{name: "missing teapot.yml", args: []string{"app", "-f", filepath.Join(t.TempDir(), "teapot.yml"), "--app", "expo"}, want: ExitInvalidConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runAdd(tt.args, &stdout, &stderr); code != tt.want {
+				t.Errorf("Expected exit code %d, got %d (stderr: %s)", tt.want, code, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,208 @@
+// Package cli implements Teapot's command line interface. Every subcommand shares the
+// same flag handling, help output and exit codes; the non-interactive commands read and
+// write plain text so that projects can be managed from scripts and CI jobs without a TTY.
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Exit codes returned by the commands
+const (
+	// ExitOK indicates the command completed successfully
+	ExitOK = 0
+	// ExitFailure indicates the command failed while doing its work
+	ExitFailure = 1
+	// ExitUsage indicates invalid flags or arguments
+	ExitUsage = 2
+	// ExitInvalidConfig indicates teapot.yml could not be loaded or is invalid
+	ExitInvalidConfig = 3
+)
+
+// Command describes a teapot subcommand.
+type Command struct {
+	// Name is the word used to invoke the command
+	Name string
+	// Args is the synopsis of the positional arguments, shown in help output
+	Args string
+	// Summary is the one-line description shown in the command list
+	Summary string
+	// Run executes the command with the arguments following its name and returns the exit code
+	Run func(args []string, stdout, stderr io.Writer) int
+}
+
+// Commands returns every subcommand in the order shown in help output.
+func Commands() []Command {
+	return []Command{
+		{
+			Name:    "init",
+			Args:    "[name]",
+			Summary: "Create a new project with the interactive wizard",
+			Run:     runInit,
+		},
+		{
+			Name:    "generate",
+			Summary: "Generate a project from teapot.yml without the interactive wizard",
+			Run:     runGenerate,
+		},
+		{
+			Name:    "upgrade",
+			Summary: "Merge template changes into a generated project",
+			Run:     runUpgrade,
+		},
+		{
+			Name:    "add",
+			Args:    "app",
+			Summary: "Add an application to an existing Teapot project",
+			Run:     runAdd,
+		},
+		{
+			Name:    "remove",
+			Args:    "app <id>",
+			Summary: "Remove an application and its references from a Teapot project",
+			Run:     runRemove,
+		},
+		{
+			Name:    "migrate-config",
+			Summary: "Rewrite teapot.yml in the current format",
+			Run:     runMigrateConfig,
+		},
+		{
+			Name:    "schema",
+			Summary: "Print the JSON Schema of teapot.yml for editor completion and validation",
+			Run:     runSchema,
+		},
+		{
+			Name:    "validate",
+			Summary: "Check that teapot.yml is valid without generating anything",
+			Run:     runValidate,
+		},
+		{
+			Name:    "doctor",
+			Summary: "Check that the tools generated projects rely on are installed",
+			Run:     runDoctor,
+		},
+		{
+			Name:    "version",
+			Summary: "Print the Teapot version",
+			Run:     runVersion,
+		},
+	}
+}
+
+// findCommand returns the subcommand with the given name
+func findCommand(name string) (Command, bool) {
+	for _, cmd := range Commands() {
+		if cmd.Name == name {
+			return cmd, true
+		}
+	}
+	return Command{}, false
+}
+
+// Run dispatches the command line arguments (without the program name) to a
+// subcommand and returns the process exit code. Without arguments the
+// interactive wizard is started.
+func Run(args []string, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		return runInit(nil, stdout, stderr)
+	}
+
+	switch args[0] {
+	case "-h", "--help", "help":
+		if len(args) > 1 {
+			cmd, ok := findCommand(args[1])
+			if !ok {
+				fmt.Fprintf(stderr, "teapot: unknown command %q\n", args[1])
+				return ExitUsage
+			}
+			return cmd.Run([]string{"-h"}, stdout, stdout)
+		}
+		printUsage(stdout)
+		return ExitOK
+	case "-v", "--version":
+		return runVersion(nil, stdout, stderr)
+	}
+
+	cmd, ok := findCommand(args[0])
+	if !ok {
+		fmt.Fprintf(stderr, "teapot: unknown command %q\n\n", args[0])
+		printUsage(stderr)
+		return ExitUsage
+	}
+	return cmd.Run(args[1:], stdout, stderr)
+}
+
+// printUsage writes the top-level help text
+func printUsage(w io.Writer) {
+	fmt.Fprintln(w, "Teapot bootstraps modern full-stack monorepos.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Usage:")
+	fmt.Fprintln(w, "  teapot <command> [flags]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Commands:")
+	for _, cmd := range Commands() {
+		fmt.Fprintf(w, "  %-18s %s\n", strings.TrimSpace(cmd.Name+" "+cmd.Args), cmd.Summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "Run 'teapot help <command>' for details on a command.")
+}
+
+// newFlagSet creates the flag set for a command with the shared help output
+func newFlagSet(name string, stderr io.Writer) *flag.FlagSet {
+	cmd, _ := findCommand(name)
+	fs := flag.NewFlagSet(cmd.Name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "Usage: %s\n\n", strings.Join(strings.Fields("teapot "+cmd.Name+" "+cmd.Args+" [flags]"), " "))
+		fmt.Fprintln(stderr, cmd.Summary)
+
+		hasFlags := false
+		fs.VisitAll(func(*flag.Flag) { hasFlags = true })
+		if hasFlags {
+			fmt.Fprintln(stderr)
+			fmt.Fprintln(stderr, "Flags:")
+			fs.PrintDefaults()
+		}
+	}
+	return fs
+}
+
+// parseFlags parses the command flags, which may appear before or after the
+// positional arguments, and checks the number of positional arguments. It returns
+// the positional arguments, or false together with the exit code when the command
+// should stop because help was requested or the arguments are invalid.
+func parseFlags(fs *flag.FlagSet, args []string, maxArgs int) ([]string, bool, int) {
+	var positional []string
+	for {
+		if err := fs.Parse(args); err != nil {
+			if err == flag.ErrHelp {
+				return nil, false, ExitOK
+			}
+			return nil, false, ExitUsage
+		}
+		if fs.NArg() == 0 {
+			break
+		}
+		positional = append(positional, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+
+	if len(positional) > maxArgs {
+		fmt.Fprintf(fs.Output(), "teapot %s: unexpected argument %q\n", fs.Name(), positional[maxArgs])
+		fs.Usage()
+		return nil, false, ExitUsage
+	}
+	return positional, true, ExitOK
+}
+
+// fileFlag registers the -f/--file flag shared by the commands that read teapot.yml
+func fileFlag(fs *flag.FlagSet) *string {
+	file := new(string)
+	fs.StringVar(file, "f", "teapot.yml", "path to the teapot.yml `file`")
+	fs.StringVar(file, "file", "teapot.yml", "path to the teapot.yml `file`")
+	return file
+}
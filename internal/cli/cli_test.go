@@ -0,0 +1,73 @@
+package cli
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestRun_Help(t *testing.T) {
+	for _, args := range [][]string{{"help"}, {"-h"}, {"--help"}} {
+		var stdout, stderr bytes.Buffer
+		if code := Run(args, &stdout, &stderr); code != ExitOK {
+			t.Errorf("Expected %v to exit with %d, got %d", args, ExitOK, code)
+		}
+		for _, cmd := range Commands() {
+			if !strings.Contains(stdout.String(), cmd.Name) {
+				t.Errorf("Expected %v to list command %s, got:\n%s", args, cmd.Name, stdout.String())
+			}
+		}
+	}
+}
+
+func TestRun_CommandHelp(t *testing.T) {
+	for _, cmd := range Commands() {
+		t.Run(cmd.Name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := Run([]string{"help", cmd.Name}, &stdout, &stderr); code != ExitOK {
+				t.Errorf("Expected exit code %d, got %d", ExitOK, code)
+			}
+			if !strings.Contains(stdout.String(), "Usage: teapot "+cmd.Name) {
+				t.Errorf("Expected usage for %s, got:\n%s", cmd.Name, stdout.String())
+			}
+
+			stdout.Reset()
+			stderr.Reset()
+			if code := Run([]string{cmd.Name, "-h"}, &stdout, &stderr); code != ExitOK {
+				t.Errorf("Expected -h to exit with %d, got %d", ExitOK, code)
+			}
+			if !strings.Contains(stderr.String(), cmd.Summary) {
+				t.Errorf("Expected -h to print the summary, got:\n%s", stderr.String())
+			}
+		})
+	}
+}
+
+func TestRun_UnknownCommand(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"brew"}, &stdout, &stderr); code != ExitUsage {
+		t.Errorf("Expected exit code %d, got %d", ExitUsage, code)
+	}
+	if !strings.Contains(stderr.String(), `unknown command "brew"`) {
+		t.Errorf("Expected unknown command error, got:\n%s", stderr.String())
+	}
+}
+
+func TestRun_Version(t *testing.T) {
+	for _, args := range [][]string{{"version"}, {"--version"}} {
+		var stdout, stderr bytes.Buffer
+		if code := Run(args, &stdout, &stderr); code != ExitOK {
+			t.Errorf("Expected %v to exit with %d, got %d", args, ExitOK, code)
+		}
+		if stdout.String() != "teapot "+Version+"\n" {
+			t.Errorf("Expected version output, got %q", stdout.String())
+		}
+	}
+}
+
+func TestRun_Add(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"add"}, &stdout, &stderr); code != ExitUsage {
+		t.Errorf("Expected missing kind to exit with %d, got %d", ExitUsage, code)
+	}
+}
@@ -0,0 +1,226 @@
+package cli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+
+	errs "teapot/internal/errors"
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// lookPath finds an executable on PATH. It is a variable so tests can fake the toolchain.
+var lookPath = exec.LookPath
+
+// toolVersion runs the version command of a tool found at path and returns its output.
+// It is a variable so tests can fake the toolchain.
+var toolVersion = func(tool doctorTool, path string) (string, error) {
+	output, err := exec.Command(path, tool.VersionArgs...).Output()
+	return string(output), err
+}
+
+// doctorTool is an executable generated projects can rely on
+type doctorTool struct {
+	// Name identifies the tool in the report
+	Name string
+	// Executable is looked up on PATH
+	Executable string
+	// VersionArgs are passed to the executable to print its version
+	VersionArgs []string
+	// MinVersion is the oldest supported major.minor version, empty if any version works
+	MinVersion string
+	// Install tells how to install the tool
+	Install string
+}
+
+// doctorTools lists the tools doctor checks, in report order
+var doctorTools = []doctorTool{
+	{Name: "git", Executable: "git", VersionArgs: []string{"--version"}, Install: "install Git from https://git-scm.com/downloads"},
+	{Name: "node", Executable: "node", VersionArgs: []string{"--version"}, MinVersion: "18.0", Install: "install Node.js 18 or newer from https://nodejs.org"},
+	{Name: "bun", Executable: "bun", VersionArgs: []string{"--version"}, MinVersion: "1.0", Install: "curl -fsSL https://bun.sh/install | bash"},
+	{Name: "pnpm", Executable: "pnpm", VersionArgs: []string{"--version"}, Install: "npm install -g pnpm"},
+	{Name: "npm", Executable: "npm", VersionArgs: []string{"--version"}, Install: "npm comes with Node.js, install it from https://nodejs.org"},
+	{Name: "yarn", Executable: "yarn", VersionArgs: []string{"--version"}, Install: "corepack enable yarn"},
+	{Name: "docker", Executable: "docker", VersionArgs: []string{"--version"}, Install: "install Docker from https://docs.docker.com/get-docker/"},
+	{Name: "docker compose", Executable: "docker", VersionArgs: []string{"compose", "version"}, Install: "install the Compose plugin from https://docs.docker.com/compose/install/"},
+}
+
+// defaultRequirements are checked when there is no teapot.yml
+var defaultRequirements = map[string][]string{
+	"git":  {"version control and Git hooks"},
+	"node": {"running JavaScript tooling"},
+	"bun":  {"installing dependencies and running workspace scripts"},
+}
+
+// projectRequirements returns why a project needs each tool, keyed by tool name
+func projectRequirements(project models.ProjectConfig) map[string][]string {
+	requirements := make(map[string][]string)
+	need := func(tool, reason string) {
+		requirements[tool] = append(requirements[tool], reason)
+	}
+
+	pm := string(generator.ProjectPackageManager(project))
+	need(pm, "installing dependencies and running workspace scripts")
+	if pm != string(models.PackageManagerBun) {
+		need("node", "running "+pm)
+	}
+
+	for _, app := range project.Applications {
+		if app.Type == models.AppTypeExpo {
+			need("node", fmt.Sprintf("the Expo app %s", app.Name))
+		}
+	}
+	if project.DevTools.Husky {
+		need("git", "Husky git hooks")
+	}
+	if project.Infrastructure.Docker {
+		need("docker", "building the Dockerfiles")
+	}
+	if contains(project.CIPipeline.Features, "docker") {
+		need("docker", "building images like the CI pipeline")
+	}
+	if project.Infrastructure.DockerCompose {
+		need("docker", "running docker-compose.yml")
+		need("docker compose", "running docker-compose.yml")
+	}
+	return requirements
+}
+
+// toolCheck is the result of checking one tool
+type toolCheck struct {
+	tool    doctorTool
+	path    string
+	version string
+	// reasons explains why the tool is required, empty for optional tools
+	reasons []string
+	// err describes why a required tool is not usable, nil if it is
+	err *errs.TeapotError
+}
+
+// versionPattern finds the version number in the output of a version command
+var versionPattern = regexp.MustCompile(`\d+\.\d+(\.\d+)?`)
+
+// checkTool looks up a tool and checks its version against the tool's minimum
+func checkTool(tool doctorTool, reasons []string) toolCheck {
+	check := toolCheck{tool: tool, reasons: reasons}
+	needed := strings.Join(reasons, ", ")
+
+	path, err := lookPath(tool.Executable)
+	if err == nil {
+		var output string
+		if output, err = toolVersion(tool, path); err == nil {
+			check.path = path
+			check.version = versionPattern.FindString(output)
+		}
+	}
+	switch {
+	case len(reasons) == 0:
+		// Optional tools are only reported
+	case err != nil:
+		check.err = errs.NewSystemError(fmt.Sprintf("%s not found, needed for %s", tool.Name, needed), err)
+	case tool.MinVersion != "" && check.version != "" && olderVersion(check.version, tool.MinVersion):
+		check.err = errs.NewValidationError(fmt.Sprintf("%s %s is older than %s, needed for %s", tool.Name, check.version, tool.MinVersion, needed), nil)
+	}
+	return check
+}
+
+// olderVersion reports whether version is older than the major.minor version minimum
+func olderVersion(version, minimum string) bool {
+	have, want := strings.Split(version, "."), strings.Split(minimum, ".")
+	for i := 0; i < 2; i++ {
+		a, _ := strconv.Atoi(have[i])
+		b, _ := strconv.Atoi(want[i])
+		if a != b {
+			return a < b
+		}
+	}
+	return false
+}
+
+// runDoctor checks the tools generated projects rely on. With a teapot.yml the
+// required tools follow from the project, otherwise git, node and bun are required.
+func runDoctor(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("doctor", stderr)
+	file := fileFlag(fs)
+	output := outputFlag(fs, "output `format`: "+formatText+" or "+formatJSON+" (one event per line)")
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+	format := *output
+	if !parseFormat("doctor", format, stderr, formatText, formatJSON) {
+		return ExitUsage
+	}
+
+	// A missing teapot.yml is only an error when it was asked for
+	explicit := false
+	fs.Visit(func(f *flag.Flag) { explicit = explicit || f.Name == "f" || f.Name == "file" })
+
+	r := newReporter("doctor", format, stdout, stderr)
+	requirements := defaultRequirements
+	if _, err := os.Stat(*file); err == nil || explicit {
+		project, err := r.loadTeapotYAML(*file)
+		if err != nil {
+			r.fail(err, errs.ErrorTypeValidation)
+			return r.finish(ExitInvalidConfig)
+		}
+		requirements = projectRequirements(project)
+		r.printf("Checking the tools %s needs (%s)\n", project.Name, *file)
+	} else {
+		r.printf("Checking the tools generated projects need\n")
+	}
+
+	problems, required := 0, 0
+	for _, tool := range doctorTools {
+		check := checkTool(tool, requirements[tool.Name])
+		if len(check.reasons) > 0 {
+			required++
+		}
+		if check.err != nil {
+			problems++
+		}
+		printToolCheck(r, check)
+	}
+
+	if problems > 0 {
+		r.printf("%d of %d required tools missing or outdated\n", problems, required)
+		return r.finish(ExitFailure)
+	}
+	r.printf("All required tools found\n")
+	return r.finish(ExitOK)
+}
+
+// printToolCheck reports the result of checking one tool
+func printToolCheck(r *reporter, check toolCheck) {
+	event := jsonEvent{
+		Event:   eventCheck,
+		Name:    check.tool.Name,
+		Path:    check.path,
+		Version: check.version,
+		Reason:  strings.Join(check.reasons, ", "),
+	}
+
+	version := check.version
+	if version == "" {
+		version = "?"
+	}
+	switch {
+	case check.err != nil:
+		r.printf("✗ %-14s %s\n", check.tool.Name, check.err.Message)
+		r.printf("    hint: %s\n", check.tool.Install)
+		event.Hint = check.tool.Install
+		event.Error = newJSONError(check.err)
+	case check.path == "":
+		r.printf("- %-14s not found (optional)\n", check.tool.Name)
+	case len(check.reasons) == 0:
+		r.printf("✓ %-14s %-8s %s (optional)\n", check.tool.Name, version, check.path)
+	default:
+		r.printf("✓ %-14s %-8s %s\n", check.tool.Name, version, check.path)
+	}
+	r.emit(event)
+}
@@ -0,0 +1,186 @@
+package cli
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// stubLookPath fakes the toolchain so that only the given tools are installed.
+// Installed tools report version 20.1.0.
+func stubLookPath(t *testing.T, installed ...string) {
+	t.Helper()
+
+	versions := make(map[string]string)
+	for _, tool := range installed {
+		versions[tool] = "v20.1.0"
+	}
+	stubToolchain(t, versions)
+}
+
+// stubToolchain fakes the toolchain: versions maps the installed tools to the
+// output of their version command. Executables are found when any of their tools is installed.
+func stubToolchain(t *testing.T, versions map[string]string) {
+	t.Helper()
+
+	originalLookPath, originalVersion := lookPath, toolVersion
+	lookPath = func(name string) (string, error) {
+		for _, tool := range doctorTools {
+			if _, ok := versions[tool.Name]; ok && tool.Executable == name {
+				return "/usr/bin/" + name, nil
+			}
+		}
+		return "", errors.New("not found")
+	}
+	toolVersion = func(tool doctorTool, path string) (string, error) {
+		if version, ok := versions[tool.Name]; ok {
+			return version + "\n", nil
+		}
+		return "", errors.New("unknown command")
+	}
+	t.Cleanup(func() { lookPath, toolVersion = originalLookPath, originalVersion })
+}
+
+// writeDoctorTeapotYAML writes a teapot.yml for project and returns its path
+func writeDoctorTeapotYAML(t *testing.T, project models.ProjectConfig) string {
+	t.Helper()
+
+	content, err := generator.GenerateTeapotYAML(project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "teapot.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunDoctor(t *testing.T) {
+	stubLookPath(t, "git", "node", "bun")
+
+	var stdout, stderr bytes.Buffer
+	if code := runDoctor(nil, &stdout, &stderr); code != ExitOK {
+		t.Errorf("Expected exit code %d, got %d", ExitOK, code)
+	}
+	if !strings.Contains(stdout.String(), "✓ bun") {
+		t.Errorf("Expected bun to be reported, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunDoctor_MissingTool(t *testing.T) {
+	stubLookPath(t, "git", "node")
+
+	var stdout, stderr bytes.Buffer
+	if code := runDoctor(nil, &stdout, &stderr); code != ExitFailure {
+		t.Errorf("Expected exit code %d, got %d", ExitFailure, code)
+	}
+	if !strings.Contains(stdout.String(), "✗ bun") {
+		t.Errorf("Expected bun to be reported missing, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunDoctor_JSON(t *testing.T) {
+	stubLookPath(t, "git", "node")
+
+	var stdout, stderr bytes.Buffer
+	if code := runDoctor([]string{"--output", "json"}, &stdout, &stderr); code != ExitFailure {
+		t.Errorf("Expected exit code %d, got %d", ExitFailure, code)
+	}
+
+	checks := findEvents(decodeEvents(t, stdout.String()), eventCheck)
+	if len(checks) != len(doctorTools) {
+		t.Fatalf("Expected a check event per tool, got %d", len(checks))
+	}
+	for _, check := range checks {
+		missing := check.Name == "bun"
+		if (check.Error != nil) != missing {
+			t.Errorf("Expected %s missing to be %v, got %+v", check.Name, missing, check.Error)
+		}
+	}
+}
+
+func TestProjectRequirements(t *testing.T) {
+	project := models.ProjectConfig{
+		Name:         "demo",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{
+			{ID: "app-expo", Name: "mobile", Type: models.AppTypeExpo},
+			{ID: "app-nest", Name: "api", Type: models.AppTypeNest},
+		},
+		DevTools:       models.DevTools{Linting: "biome", Husky: true},
+		Infrastructure: models.Infrastructure{DockerCompose: true},
+	}
+
+	got := projectRequirements(project)
+	for _, tool := range []string{"bun", "node", "git", "docker", "docker compose"} {
+		if len(got[tool]) == 0 {
+			t.Errorf("Expected %s to be required, got %v", tool, got)
+		}
+	}
+	if len(got["pnpm"]) > 0 {
+		t.Errorf("Expected pnpm to be optional, got %v", got["pnpm"])
+	}
+
+	// Without Expo, Husky or Docker only the package manager is required
+	minimal := projectRequirements(models.ProjectConfig{Applications: []models.Application{{Type: models.AppTypeNext}}})
+	if want := map[string][]string{"bun": minimal["bun"]}; len(minimal["bun"]) == 0 || !reflect.DeepEqual(minimal, want) {
+		t.Errorf("Expected only bun to be required, got %v", minimal)
+	}
+
+	// Other package managers run on Node.js
+	pnpm := projectRequirements(models.ProjectConfig{PackageManager: models.PackageManagerPnpm})
+	if len(pnpm["pnpm"]) == 0 || len(pnpm["node"]) == 0 || len(pnpm["bun"]) > 0 {
+		t.Errorf("Expected pnpm and node to be required instead of bun, got %v", pnpm)
+	}
+}
+
+func TestRunDoctor_TeapotYAML(t *testing.T) {
+	file := writeDoctorTeapotYAML(t, models.ProjectConfig{
+		Name:           "demo",
+		Architecture:   models.ArchitectureTurborepo,
+		Applications:   []models.Application{{ID: "app-expo", Name: "mobile", Type: models.AppTypeExpo}},
+		DevTools:       models.DevTools{Linting: "biome", Husky: true},
+		Infrastructure: models.Infrastructure{Docker: true},
+	})
+	stubToolchain(t, map[string]string{"git": "git version 2.43.0", "node": "v16.20.2", "bun": "1.1.8"})
+
+	var stdout, stderr bytes.Buffer
+	if code := runDoctor([]string{"-f", file}, &stdout, &stderr); code != ExitFailure {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitFailure, code, stderr.String())
+	}
+	for _, line := range []string{
+		"✓ git            2.43.0",
+		"✗ node           node 16.20.2 is older than 18.0, needed for the Expo app mobile",
+		"✗ docker         docker not found, needed for building the Dockerfiles\n    hint: install Docker",
+		"- pnpm           not found (optional)",
+		"2 of 4 required tools missing or outdated",
+	} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	runDoctor([]string{"-f", file, "--output", "json"}, &stdout, &stderr)
+	types := make(map[string]string)
+	for _, check := range findEvents(decodeEvents(t, stdout.String()), eventCheck) {
+		if check.Error != nil {
+			types[check.Name] = check.Error.Type
+		}
+	}
+	if types["node"] != "Validation" || types["docker"] != "System" || len(types) != 2 {
+		t.Errorf("Expected an outdated node and a missing docker, got %v", types)
+	}
+
+	if code := runDoctor([]string{"-f", filepath.Join(t.TempDir(), "missing.yml")}, &stdout, &stderr); code != ExitInvalidConfig {
+		t.Errorf("Expected a missing -f file to exit with %d, got %d", ExitInvalidConfig, code)
+	}
+}
@@ -0,0 +1,162 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+
+	errs "teapot/internal/errors"
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// loadTeapotYAML loads a teapot.yml file and prints its deprecation warnings to stderr
+func loadTeapotYAML(command, file string, stderr io.Writer) (models.ProjectConfig, error) {
+	return newReporter(command, formatText, io.Discard, stderr).loadTeapotYAML(file)
+}
+
+// loadProject loads a teapot.yml file and builds its generation plan
+func loadProject(r *reporter, file string) (models.ProjectConfig, *generator.Plan, error) {
+	project, err := r.loadTeapotYAML(file)
+	if err != nil {
+		return project, nil, err
+	}
+
+	plan, err := generator.BuildPlan(project)
+	if err != nil {
+		return project, nil, fmt.Errorf("invalid configuration in %s: %w", file, err)
+	}
+
+	return project, plan, nil
+}
+
+// runGenerate generates a project from a teapot.yml file, printing one line per
+// step and file to stdout, or one JSON event per line with --output json.
+func runGenerate(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("generate", stderr)
+	file := fileFlag(fs)
+	var dir string
+	fs.StringVar(&dir, "d", "", "output `directory` (defaults to the project name)")
+	fs.StringVar(&dir, "dir", "", "output `directory` (defaults to the project name)")
+	// --output used to name the directory; values other than a format still do
+	var output string
+	usage := "output `format`: " + formatText + " or " + formatJSON + " (one event per line)"
+	fs.StringVar(&output, "o", "", usage)
+	fs.StringVar(&output, "output", "", usage)
+	format := formatText
+	fs.StringVar(&format, "format", formatText, "alias for --output")
+	var dryRun bool
+	fs.BoolVar(&dryRun, "dry-run", false, "print every file that would be generated without writing anything")
+	var onConflict string
+	fs.StringVar(&onConflict, "on-conflict", string(generator.ConflictAbort), "what to do when the output directory already has content: "+conflictPolicyNames())
+
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+	deprecatedDir := false
+	switch output {
+	case "":
+	case formatText, formatJSON:
+		format = output
+	default:
+		if dir != "" {
+			fmt.Fprintf(stderr, "teapot generate: --output %q is not a format (choose from %s, %s); use --dir for the output directory\n", output, formatText, formatJSON)
+			return ExitUsage
+		}
+		dir, deprecatedDir = output, true
+	}
+	if !parseFormat("generate", format, stderr, formatText, formatJSON) {
+		return ExitUsage
+	}
+
+	policy, err := generator.ParseConflictPolicy(onConflict)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot generate: %v\n", err)
+		return ExitUsage
+	}
+
+	r := newReporter("generate", format, stdout, stderr)
+	if deprecatedDir {
+		r.warning(errs.NewDeprecationError(fmt.Sprintf("--output %s names the output directory, which is deprecated; use --dir %s", dir, dir), nil))
+	}
+	project, plan, err := loadProject(r, *file)
+	if err != nil {
+		r.fail(err, errs.ErrorTypeValidation)
+		return r.finish(ExitInvalidConfig)
+	}
+
+	if dir == "" {
+		dir = project.Name
+	}
+	return r.finish(writeProject(r, project, plan, dir, policy, dryRun))
+}
+
+// writeProject generates a planned project into output and reports every
+// step and file. With dryRun the planned files are reported instead.
+func writeProject(r *reporter, project models.ProjectConfig, plan *generator.Plan, output string, policy generator.ConflictPolicy, dryRun bool) int {
+	state, err := generator.InspectTarget(output)
+	if err != nil {
+		r.fail(err, errs.ErrorTypeSystem)
+		return ExitFailure
+	}
+	if state.HasContent() && policy == generator.ConflictAbort {
+		r.fail(fmt.Errorf("%s already exists and is %s", output, state), errs.ErrorTypeValidation)
+		if r.command == "generate" && !r.json() {
+			fmt.Fprintln(r.stderr, "Use --on-conflict merge to write only missing files, or --on-conflict overwrite to back it up and start fresh.")
+		}
+		return ExitFailure
+	}
+
+	r.emit(jsonEvent{Event: eventStarted, Project: project.Name, Path: output, Steps: len(plan.Steps), Files: plan.FileCount()})
+	if dryRun {
+		r.printf("Dry run: %s would be generated into %s\n\n", project.Name, output)
+		r.printf("%s", generator.FormatPlanForDisplay(plan))
+		for i, step := range plan.Steps {
+			for _, file := range step.Files {
+				r.emit(jsonEvent{Event: eventFilePlanned, Step: i + 1, Name: step.Name, Path: file.Path})
+			}
+		}
+		return ExitOK
+	}
+
+	r.printf("Generating %s into %s (%d files)\n", project.Name, output, plan.FileCount())
+
+	engine := generator.NewEngine(project, output)
+	engine.SetConflictPolicy(policy)
+	err = engine.Generate(context.Background(), plan, func(event generator.Event) {
+		switch event.Type {
+		case generator.EventStepStarted:
+			r.printf("[%d/%d] %s\n", event.Step+1, len(plan.Steps), plan.Steps[event.Step].Description)
+			r.emit(jsonEvent{Event: eventStepStarted, Step: event.Step + 1, Steps: len(plan.Steps), Name: event.StepName})
+		case generator.EventFileWritten:
+			r.printf("  + %s\n", event.Path)
+			r.emit(jsonEvent{Event: eventFileWritten, Step: event.Step + 1, Path: event.Path})
+		case generator.EventFileSkipped:
+			r.printf("  = %s (kept existing)\n", event.Path)
+			r.emit(jsonEvent{Event: eventFileSkipped, Step: event.Step + 1, Path: event.Path})
+		case generator.EventBackupCreated:
+			r.printf("Moved the existing %s to %s\n", output, event.Path)
+			r.emit(jsonEvent{Event: eventBackupCreated, Path: event.Path})
+		case generator.EventStepFinished:
+			r.printf("  ✓ %s\n", event.StepName)
+			r.emit(jsonEvent{Event: eventStepFinished, Step: event.Step + 1, Steps: len(plan.Steps), Name: event.StepName})
+		}
+	})
+	if err != nil {
+		r.fail(err, errs.ErrorTypeSystem)
+		return ExitFailure
+	}
+
+	r.printf("Done. Project generated in %s\n", output)
+	return ExitOK
+}
+
+// conflictPolicyNames lists the --on-conflict values
+func conflictPolicyNames() string {
+	names := make([]string, len(generator.ConflictPolicies))
+	for i, policy := range generator.ConflictPolicies {
+		names[i] = string(policy)
+	}
+	return strings.Join(names, ", ")
+}
@@ -0,0 +1,261 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// writeTeapotYAML writes a teapot.yml for a small project into dir and returns its path
+func writeTeapotYAML(t *testing.T, dir string) string {
+	t.Helper()
+
+	project := models.ProjectConfig{
+		Name:         "ci-project",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{
+			{ID: "app-react", Name: "web", Type: models.AppTypeReact, Options: map[string]interface{}{}},
+		},
+		DevTools: models.DevTools{Linting: "biome", TypeScript: true},
+	}
+	content, err := generator.GenerateTeapotYAML(project)
+	if err != nil {
+		t.Fatalf("Failed to generate teapot.yml: %v", err)
+	}
+
+	path := filepath.Join(dir, "teapot.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write teapot.yml: %v", err)
+	}
+	return path
+}
+
+func TestRunGenerate(t *testing.T) {
+	dir := t.TempDir()
+	file := writeTeapotYAML(t, dir)
+	output := filepath.Join(dir, "out")
+
+	var stdout, stderr bytes.Buffer
+	code := runGenerate([]string{"-f", file, "--dir", output}, &stdout, &stderr)
+	if code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	for _, path := range []string{"package.json", "teapot.yml", "apps/web/package.json"} {
+		if _, err := os.Stat(filepath.Join(output, path)); err != nil {
+			t.Errorf("Expected %s to be generated: %v", path, err)
+		}
+	}
+
+	for _, line := range []string{"[1/", "  + apps/web/package.json", "  ✓ Apps scaffolded", "Done."} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, stdout.String())
+		}
+	}
+}
+
+func TestRunGenerate_ExitCodes(t *testing.T) {
+	dir := t.TempDir()
+	valid := writeTeapotYAML(t, dir)
+
+	invalid := filepath.Join(dir, "invalid.yml")
+	if err := os.WriteFile(invalid, []byte("version: \"1.0\"\nunknown: true\n"), 0644); err != nil {
+		t.Fatalf("Failed to write invalid.yml: %v", err)
+	}
+
+	badName := filepath.Join(dir, "bad-name.yml")
+	content, _ := os.ReadFile(valid)
+	if err := os.WriteFile(badName, bytes.Replace(content, []byte("name: ci-project"), []byte("name: ../escape"), 1), 0644); err != nil {
+		t.Fatalf("Failed to write bad-name.yml: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "unknown flag", args: []string{"--nope"}, want: ExitUsage},
+		{name: "extra argument", args: []string{"-f", valid, "extra"}, want: ExitUsage},
+		{name: "unknown conflict policy", args: []string{"-f", valid, "--on-conflict", "replace"}, want: ExitUsage},
+		{name: "missing file", args: []string{"-f", filepath.Join(dir, "missing.yml")}, want: ExitInvalidConfig},
+		{name: "unknown key", args: []string{"-f", invalid}, want: ExitInvalidConfig},
+		{name: "invalid project", args: []string{"-f", badName}, want: ExitInvalidConfig},
+		{name: "help", args: []string{"-h"}, want: ExitOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runGenerate(tt.args, &stdout, &stderr); code != tt.want {
+				t.Errorf("Expected exit code %d, got %d (stderr: %s)", tt.want, code, stderr.String())
+			}
+		})
+	}
+}
+
+func TestRunValidate(t *testing.T) {
+	dir := t.TempDir()
+	file := writeTeapotYAML(t, dir)
+
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"-f", file}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "is valid") {
+		t.Errorf("Expected validation success, got:\n%s", stdout.String())
+	}
+	if _, err := os.Stat(filepath.Join(dir, "ci-project")); !os.IsNotExist(err) {
+		t.Error("Expected validate not to generate any files")
+	}
+
+	stdout.Reset()
+	if code := runValidate([]string{"-f", filepath.Join(dir, "missing.yml")}, &stdout, &stderr); code != ExitInvalidConfig {
+		t.Errorf("Expected exit code %d, got %d", ExitInvalidConfig, code)
+	}
+}
+
+func TestRunGenerate_DryRun(t *testing.T) {
+	dir := t.TempDir()
+	file := writeTeapotYAML(t, dir)
+	output := filepath.Join(dir, "out")
+
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", file, "--dir", output, "--dry-run"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	if _, err := os.Stat(output); !os.IsNotExist(err) {
+		t.Error("Expected dry run not to create the output directory")
+	}
+	for _, expected := range []string{"apps/web/package.json", "react/", "files,"} {
+		if !strings.Contains(stdout.String(), expected) {
+			t.Errorf("Expected dry run output to contain %q, got:\n%s", expected, stdout.String())
+		}
+	}
+}
+
+func TestRunGenerate_OnConflict(t *testing.T) {
+	dir := t.TempDir()
+	file := writeTeapotYAML(t, dir)
+	output := filepath.Join(dir, "out")
+
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", file, "--dir", output}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	tests := []struct {
+		name     string
+		args     []string
+		want     int
+		expected string
+	}{
+		{name: "abort by default", want: ExitFailure, expected: "already exists and is a teapot project"},
+		{name: "merge", args: []string{"--on-conflict", "merge"}, want: ExitOK, expected: "  = package.json (kept existing)"},
+		{name: "overwrite", args: []string{"--on-conflict", "overwrite"}, want: ExitOK, expected: "Moved the existing " + output + " to " + output + ".backup-"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"-f", file, "--dir", output}, tt.args...)
+			if code := runGenerate(args, &stdout, &stderr); code != tt.want {
+				t.Fatalf("Expected exit code %d, got %d (stderr: %s)", tt.want, code, stderr.String())
+			}
+			if out := stdout.String() + stderr.String(); !strings.Contains(out, tt.expected) {
+				t.Errorf("Expected output to contain %q, got:\n%s", tt.expected, out)
+			}
+		})
+	}
+}
+
+func TestRunGenerate_JSON(t *testing.T) {
+	dir := t.TempDir()
+	file := writeTeapotYAML(t, dir)
+	output := filepath.Join(dir, "out")
+
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", file, "--dir", output, "--output", "json"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	events := decodeEvents(t, stdout.String())
+	if events[0].Event != eventStarted || events[0].Project != "ci-project" || events[0].Files == 0 {
+		t.Errorf("Expected a started event with the project, got %+v", events[0])
+	}
+	started, finished := findEvents(events, eventStepStarted), findEvents(events, eventStepFinished)
+	if len(started) == 0 || len(started) != len(finished) || started[0].Step != 1 {
+		t.Errorf("Expected matching step events numbered from 1, got %d started and %d finished", len(started), len(finished))
+	}
+	if written := findEvents(events, eventFileWritten); len(written) != events[0].Files {
+		t.Errorf("Expected a file_written event per file, got %d of %d", len(written), events[0].Files)
+	}
+
+	// Failures are events too, with the TeapotError details
+	stdout.Reset()
+	if code := runGenerate([]string{"-f", file, "--dir", output, "--output", "json"}, &stdout, &stderr); code != ExitFailure {
+		t.Fatalf("Expected exit code %d, got %d", ExitFailure, code)
+	}
+	failures := findEvents(decodeEvents(t, stdout.String()), eventError)
+	if len(failures) != 1 || !strings.Contains(failures[0].Error.Message, "already exists") || failures[0].Error.Type != "Validation" {
+		t.Errorf("Expected an error event for the existing directory, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunGenerate_JSONWarnings(t *testing.T) {
+	file := writeLegacyTeapotYAML(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", file, "--dry-run", "--output", "json"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if stderr.Len() > 0 {
+		t.Errorf("Expected warnings as events instead of stderr, got:\n%s", stderr.String())
+	}
+
+	events := decodeEvents(t, stdout.String())
+	warnings := findEvents(events, eventWarning)
+	if len(warnings) == 0 || warnings[0].Error.Type != "Deprecation" {
+		t.Errorf("Expected deprecation warning events, got:\n%s", stdout.String())
+	}
+	if len(findEvents(events, eventFilePlanned)) == 0 || len(findEvents(events, eventFileWritten)) > 0 {
+		t.Errorf("Expected --dry-run to report planned files only, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunGenerate_OutputDirectoryAlias(t *testing.T) {
+	dir := t.TempDir()
+	file := writeTeapotYAML(t, dir)
+	output := filepath.Join(dir, "out")
+
+	// --output with a directory still works, with a deprecation warning
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", file, "--output", output}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if _, err := os.Stat(filepath.Join(output, "package.json")); err != nil {
+		t.Errorf("Expected the project to be generated into %s: %v", output, err)
+	}
+	if !strings.Contains(stderr.String(), "use --dir") {
+		t.Errorf("Expected a deprecation warning, got:\n%s", stderr.String())
+	}
+
+	// --output json is the format, never a directory named json
+	stdout.Reset()
+	if code := runGenerate([]string{"-f", file, "--dry-run", "--output", "json"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if events := decodeEvents(t, stdout.String()); events[0].Path != "ci-project" {
+		t.Errorf("Expected the project directory, got %q", events[0].Path)
+	}
+
+	stderr.Reset()
+	if code := runGenerate([]string{"-f", file, "--dir", output, "--output", "xml"}, &stdout, &stderr); code != ExitUsage {
+		t.Errorf("Expected exit code %d for an --output that is neither a format nor the directory, got %d", ExitUsage, code)
+	}
+}
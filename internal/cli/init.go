@@ -0,0 +1,267 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+	"teapot/internal/ui"
+	"teapot/internal/ui/screens"
+	"teapot/internal/validation"
+
+	tea "github.com/charmbracelet/bubbletea"
+)
+
+// runWizard starts the interactive wizard prefilled with the given answers.
+// It is a variable so tests can replace the terminal program.
+var runWizard = func(project models.ProjectConfig) error {
+	p := tea.NewProgram(
+		ui.NewModelWithProject(project),
+		tea.WithAltScreen(),
+	)
+	_, err := p.Run()
+	return err
+}
+
+// listFlag is a repeatable string flag
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
+
+// initFlags holds the wizard answers given on the command line
+type initFlags struct {
+	arch       string
+	pm         string
+	apps       listFlag
+	lint       string
+	ci         string
+	ciFeatures listFlag
+	ai         listFlag
+	yes        bool
+}
+
+// runInit starts the wizard, skipping the screens answered on the command line.
+// With --yes the remaining screens take their defaults and the project is
+// generated without the wizard.
+func runInit(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("init", stderr)
+
+	var flags initFlags
+	fs.StringVar(&flags.arch, "arch", "", "project `architecture`: "+strings.Join(architectureKeys(), ", "))
+	fs.StringVar(&flags.pm, "pm", "", "package `manager`: "+strings.Join(screens.PackageManagers(), ", "))
+	fs.Var(&flags.apps, "app", "add an application as `type[:name]`, e.g. next:web (repeatable)")
+	fs.StringVar(&flags.lint, "lint", "", "linting `setup`: "+strings.Join(screens.LintingTools(), ", "))
+	fs.StringVar(&flags.ci, "ci", "", "CI/CD `provider`: "+strings.Join(screens.CIProviders(), ", "))
+	ciFeatures, _ := screens.CIFeatures()
+	fs.Var(&flags.ciFeatures, "ci-feature", "enable a CI/CD `feature` (repeatable): "+strings.Join(ciFeatures, ", "))
+	fs.Var(&flags.ai, "ai", "configure an AI `tool` (repeatable): "+strings.Join(screens.AITools(), ", "))
+	for _, name := range []string{"y", "yes", "defaults"} {
+		fs.BoolVar(&flags.yes, name, false, "accept the default of every screen not answered by a flag and generate without the wizard")
+	}
+
+	positional, ok, code := parseFlags(fs, args, 1)
+	if !ok {
+		return code
+	}
+
+	var name string
+	if len(positional) > 0 {
+		name = positional[0]
+	}
+	project, err := buildInitProject(name, flags)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot init: %v\n", err)
+		return ExitUsage
+	}
+
+	if flags.yes {
+		return runHeadlessInit(project, stdout, stderr)
+	}
+
+	if err := runWizard(project); err != nil {
+		fmt.Fprintf(stderr, "Error running Teapot: %v\n", err)
+		return ExitFailure
+	}
+	return ExitOK
+}
+
+// runHeadlessInit generates a project from the init arguments, answering every
+// other screen with its default
+func runHeadlessInit(project models.ProjectConfig, stdout, stderr io.Writer) int {
+	if project.Name == "" {
+		fmt.Fprintln(stderr, "teapot init: --yes requires a project name")
+		return ExitUsage
+	}
+
+	project = screens.Defaults().Apply(project)
+	plan, err := generator.BuildPlan(project)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot init: %v\n", err)
+		return ExitUsage
+	}
+	return writeProject(newReporter("init", formatText, stdout, stderr), project, plan, project.Name, generator.ConflictAbort, false)
+}
+
+// buildInitProject seeds a project configuration from the init arguments.
+// Every value is checked against the options the corresponding screen offers.
+func buildInitProject(name string, flags initFlags) (models.ProjectConfig, error) {
+	var project models.ProjectConfig
+
+	if name != "" {
+		if err := validation.ValidateProjectName(name); err != nil {
+			return project, err
+		}
+		project.Name = name
+	}
+
+	if flags.arch != "" {
+		arch := models.ArchitectureType(flags.arch)
+		if _, ok := models.ArchitectureNames[arch]; !ok {
+			return project, invalidChoice("--arch", flags.arch, architectureKeys())
+		}
+		project.Architecture = arch
+	}
+
+	if flags.pm != "" {
+		pm := models.PackageManagerType(flags.pm)
+		if _, ok := models.PackageManagerNames[pm]; !ok {
+			return project, invalidChoice("--pm", flags.pm, screens.PackageManagers())
+		}
+		project.PackageManager = pm
+	}
+
+	names := make(map[string]bool)
+	for _, value := range flags.apps {
+		app, err := parseAppFlag(value, project.Applications)
+		if err != nil {
+			return project, err
+		}
+		if names[app.Name] {
+			return project, fmt.Errorf("duplicate application name %q in --app %s", app.Name, value)
+		}
+		names[app.Name] = true
+		project.Applications = append(project.Applications, app)
+	}
+	if project.Architecture == models.ArchitectureSingle && len(project.Applications) > 1 {
+		return project, fmt.Errorf("--arch single supports one --app, got %d", len(project.Applications))
+	}
+
+	if flags.lint != "" {
+		if !contains(screens.LintingTools(), flags.lint) {
+			return project, invalidChoice("--lint", flags.lint, screens.LintingTools())
+		}
+		// The dev tools screen always enables these alongside the linting setup
+		project.DevTools = models.DevTools{
+			Linting:    flags.lint,
+			TypeScript: true,
+			Husky:      true,
+			LintStaged: true,
+		}
+	}
+
+	if flags.ci == "" && len(flags.ciFeatures) > 0 {
+		return project, fmt.Errorf("--ci-feature requires --ci")
+	}
+	if flags.ci != "" {
+		if !contains(screens.CIProviders(), flags.ci) {
+			return project, invalidChoice("--ci", flags.ci, screens.CIProviders())
+		}
+		keys, defaults := screens.CIFeatures()
+		features := []string(flags.ciFeatures)
+		for _, feature := range features {
+			if !contains(keys, feature) {
+				return project, invalidChoice("--ci-feature", feature, keys)
+			}
+		}
+		if len(features) == 0 {
+			features = defaults
+		}
+		if flags.ci == "skip" {
+			features = []string{}
+		}
+		project.CIPipeline = models.CIPipeline{Provider: flags.ci, Features: features}
+	}
+
+	if len(flags.ai) > 0 {
+		extensions := []string{}
+		for _, tool := range flags.ai {
+			toolExtensions, ok := screens.AIToolExtensions(tool)
+			if !ok {
+				return project, invalidChoice("--ai", tool, screens.AITools())
+			}
+			extensions = append(extensions, toolExtensions...)
+		}
+		project.AITools = models.AITools{Editor: strings.Join(flags.ai, ","), Extensions: extensions}
+	}
+
+	return project, nil
+}
+
+// parseAppFlag parses an --app value of the form type[:name] into an
+// application with an ID none of apps has
+func parseAppFlag(value string, apps []models.Application) (models.Application, error) {
+	typeName, name, hasName := strings.Cut(value, ":")
+	appType := models.AppType(typeName)
+	if _, ok := models.AppTypeNames[appType]; !ok {
+		return models.Application{}, invalidChoice("--app", typeName, appTypeKeys())
+	}
+
+	if !hasName {
+		name = screens.DefaultAppName(appType)
+	}
+	if !screens.IsValidAppName(name) {
+		return models.Application{}, fmt.Errorf("invalid application name %q in --app %s (use letters, digits, - and _)", name, value)
+	}
+
+	return models.Application{
+		ID:      generator.NewApplicationID(apps, appType),
+		Name:    name,
+		Type:    appType,
+		Options: screens.DefaultAppOptions(appType),
+	}, nil
+}
+
+// invalidChoice reports a flag value that is not one of the offered options
+func invalidChoice(flagName, value string, choices []string) error {
+	return fmt.Errorf("invalid %s %q (choose from %s)", flagName, value, strings.Join(choices, ", "))
+}
+
+// architectureKeys returns the supported architectures in wizard order
+func architectureKeys() []string {
+	return []string{
+		string(models.ArchitectureTurborepo),
+		string(models.ArchitectureSingle),
+		string(models.ArchitectureNx),
+	}
+}
+
+// appTypeKeys returns the supported application types in wizard order
+func appTypeKeys() []string {
+	return []string{
+		string(models.AppTypeReact),
+		string(models.AppTypeNext),
+		string(models.AppTypeTanStack),
+		string(models.AppTypeExpo),
+		string(models.AppTypeNest),
+		string(models.AppTypeBasicNode),
+	}
+}
+
+// contains reports whether values includes value
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
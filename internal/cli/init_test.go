@@ -0,0 +1,213 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+	"teapot/internal/ui/screens"
+)
+
+// stubWizard replaces the terminal program and records the project it was started with
+func stubWizard(t *testing.T) *[]models.ProjectConfig {
+	t.Helper()
+
+	var started []models.ProjectConfig
+	original := runWizard
+	runWizard = func(project models.ProjectConfig) error {
+		started = append(started, project)
+		return nil
+	}
+	t.Cleanup(func() { runWizard = original })
+	return &started
+}
+
+func TestRunInit(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     int
+		wantName string
+		started  bool
+	}{
+		{name: "no arguments", args: nil, want: ExitOK, wantName: "", started: true},
+		{name: "project name", args: []string{"init", "my-awesome-project"}, want: ExitOK, wantName: "my-awesome-project", started: true},
+		{name: "invalid name", args: []string{"init", "../escape"}, want: ExitUsage, started: false},
+		{name: "too many arguments", args: []string{"init", "one", "two"}, want: ExitUsage, started: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			started := stubWizard(t)
+
+			var stdout, stderr bytes.Buffer
+			if code := Run(tt.args, &stdout, &stderr); code != tt.want {
+				t.Fatalf("Expected exit code %d, got %d (stderr: %s)", tt.want, code, stderr.String())
+			}
+			if (len(*started) > 0) != tt.started {
+				t.Fatalf("Expected wizard started to be %v, got %v", tt.started, len(*started) > 0)
+			}
+			if tt.started && (*started)[0].Name != tt.wantName {
+				t.Errorf("Expected project name %q, got %q", tt.wantName, (*started)[0].Name)
+			}
+		})
+	}
+}
+
+func TestBuildInitProject(t *testing.T) {
+	flags := initFlags{
+		arch:       "turborepo",
+		apps:       listFlag{"next:web", "nest:api"},
+		lint:       "biome",
+		ci:         "github",
+		ciFeatures: listFlag{"testing", "docker"},
+		ai:         listFlag{"cursor"},
+	}
+
+	project, err := buildInitProject("my-awesome-project", flags)
+	if err != nil {
+		t.Fatalf("Expected flags to be accepted, got error: %v", err)
+	}
+
+	if project.Architecture != models.ArchitectureTurborepo {
+		t.Errorf("Expected Turborepo architecture, got %v", project.Architecture)
+	}
+	if len(project.Applications) != 2 {
+		t.Fatalf("Expected 2 applications, got %d", len(project.Applications))
+	}
+	web := project.Applications[0]
+	if web.Type != models.AppTypeNext || web.Name != "web" {
+		t.Errorf("Expected next app named web, got %s named %s", web.Type, web.Name)
+	}
+	if web.Options["tailwind"] != true {
+		t.Errorf("Expected default app options, got %v", web.Options)
+	}
+	if project.DevTools.Linting != "biome" || !project.DevTools.TypeScript {
+		t.Errorf("Expected biome with TypeScript, got %+v", project.DevTools)
+	}
+	if project.CIPipeline.Provider != "github" || len(project.CIPipeline.Features) != 2 {
+		t.Errorf("Expected GitHub with 2 features, got %+v", project.CIPipeline)
+	}
+	if project.AITools.Editor != "cursor" || len(project.AITools.Extensions) == 0 {
+		t.Errorf("Expected cursor with extensions, got %+v", project.AITools)
+	}
+}
+
+func TestBuildInitProject_UniqueIDs(t *testing.T) {
+	project, err := buildInitProject("my-awesome-project", initFlags{apps: listFlag{"next:web", "next:admin"}})
+	if err != nil {
+		t.Fatalf("Expected flags to be accepted, got error: %v", err)
+	}
+	if ids := []string{project.Applications[0].ID, project.Applications[1].ID}; ids[0] != "app-next" || ids[1] != "app-next-2" {
+		t.Errorf("Expected app-next and app-next-2, got %v", ids)
+	}
+}
+
+func TestBuildInitProject_Defaults(t *testing.T) {
+	project, err := buildInitProject("", initFlags{apps: listFlag{"expo"}, ci: "gitlab"})
+	if err != nil {
+		t.Fatalf("Expected flags to be accepted, got error: %v", err)
+	}
+
+	if project.Applications[0].Name != "mobile" {
+		t.Errorf("Expected default app name mobile, got %s", project.Applications[0].Name)
+	}
+	if len(project.CIPipeline.Features) != 2 {
+		t.Errorf("Expected the preselected CI features, got %v", project.CIPipeline.Features)
+	}
+}
+
+func TestBuildInitProject_Invalid(t *testing.T) {
+	tests := []struct {
+		name  string
+		flags initFlags
+	}{
+		{name: "unknown architecture", flags: initFlags{arch: "lerna"}},
+		{name: "unknown app type", flags: initFlags{apps: listFlag{"svelte:web"}}},
+		{name: "invalid app name", flags: initFlags{apps: listFlag{"next:my web"}}},
+		{name: "duplicate app name", flags: initFlags{apps: listFlag{"next:web", "react:web"}}},
+		{name: "single with two apps", flags: initFlags{arch: "single", apps: listFlag{"next:web", "nest:api"}}},
+		{name: "unknown linter", flags: initFlags{lint: "jslint"}},
+		{name: "unknown CI provider", flags: initFlags{ci: "travis"}},
+		{name: "unknown CI feature", flags: initFlags{ci: "github", ciFeatures: listFlag{"fuzzing"}}},
+		{name: "CI feature without provider", flags: initFlags{ciFeatures: listFlag{"testing"}}},
+		{name: "unknown AI tool", flags: initFlags{ai: listFlag{"notepad"}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := buildInitProject("", tt.flags); err == nil {
+				t.Error("Expected an error, got nil")
+			}
+		})
+	}
+}
+
+func TestRunInit_Flags(t *testing.T) {
+	started := stubWizard(t)
+
+	var stdout, stderr bytes.Buffer
+	args := []string{"init", "--arch", "nx", "demo", "--app", "next:web", "--app", "nest:api", "--lint", "biome", "--pm", "pnpm"}
+	if code := Run(args, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	project := (*started)[0]
+	if project.Name != "demo" || project.Architecture != models.ArchitectureNx || len(project.Applications) != 2 ||
+		project.PackageManager != models.PackageManagerPnpm {
+		t.Errorf("Expected flags to seed the project, got %+v", project)
+	}
+
+	for _, invalid := range [][]string{{"init", "--arch", "lerna"}, {"init", "--pm", "pip"}} {
+		if code := Run(invalid, &stdout, &stderr); code != ExitUsage {
+			t.Errorf("Expected invalid flag value in %v to exit with %d, got %d", invalid, ExitUsage, code)
+		}
+	}
+}
+
+func TestRunInit_Yes(t *testing.T) {
+	started := stubWizard(t)
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+
+	var stdout, stderr bytes.Buffer
+	if code := Run([]string{"init", "--yes", "demo", "--lint", "biome"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if len(*started) > 0 {
+		t.Error("Expected --yes to generate without the wizard")
+	}
+
+	project, err := generator.LoadTeapotYAML(filepath.Join(dir, "demo", "teapot.yml"))
+	if err != nil {
+		t.Fatalf("Expected a generated teapot.yml, got error: %v", err)
+	}
+	want := screens.Defaults()
+	if project.Architecture != want.Architecture || project.CIPipeline.Provider != want.CIProvider || project.AITools.Editor != "" {
+		t.Errorf("Expected the wizard defaults, got %+v", project)
+	}
+	if len(project.Applications) != 1 || project.Applications[0].Type != want.AppType {
+		t.Errorf("Expected one %s application, got %+v", want.AppType, project.Applications)
+	}
+	if project.DevTools.Linting != "biome" {
+		t.Errorf("Expected --lint to override the default, got %s", project.DevTools.Linting)
+	}
+
+	// A second run must not overwrite the project
+	if code := Run([]string{"init", "--defaults", "demo"}, &stdout, &stderr); code != ExitFailure {
+		t.Errorf("Expected an existing project to exit with %d, got %d", ExitFailure, code)
+	}
+	if code := Run([]string{"init", "--yes"}, &stdout, &stderr); code != ExitUsage {
+		t.Errorf("Expected --yes without a name to exit with %d, got %d", ExitUsage, code)
+	}
+}
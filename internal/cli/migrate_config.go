@@ -0,0 +1,62 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"teapot/internal/generator"
+)
+
+// runMigrateConfig rewrites teapot.yml in the current format, keeping comments and key order
+func runMigrateConfig(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("migrate-config", stderr)
+	file := fileFlag(fs)
+	var dryRun bool
+	fs.BoolVar(&dryRun, "dry-run", false, "print the migrated teapot.yml instead of writing it")
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot migrate-config: failed to read YAML file: %v\n", err)
+		return ExitInvalidConfig
+	}
+	migration, err := generator.MigrateTeapotYAML(data)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot migrate-config: %s: %v\n", *file, err)
+		return ExitInvalidConfig
+	}
+	if !migration.Migrated() {
+		fmt.Fprintf(stdout, "%s already uses the current version %s\n", *file, migration.From)
+		return ExitOK
+	}
+
+	// Never write a migrated file that would not load
+	if _, err := generator.ParseTeapotYAML(migration.Content); err != nil {
+		fmt.Fprintf(stderr, "teapot migrate-config: %s: %v\n", *file, err)
+		return ExitInvalidConfig
+	}
+
+	if dryRun {
+		fmt.Fprint(stdout, string(migration.Content))
+		return ExitOK
+	}
+
+	info, err := os.Stat(*file)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot migrate-config: %v\n", err)
+		return ExitFailure
+	}
+	if err := os.WriteFile(*file, migration.Content, info.Mode().Perm()); err != nil {
+		fmt.Fprintf(stderr, "teapot migrate-config: failed to write YAML file: %v\n", err)
+		return ExitFailure
+	}
+
+	for _, warning := range migration.Warnings {
+		fmt.Fprintf(stdout, "  ~ %s\n", warning.Message)
+	}
+	fmt.Fprintf(stdout, "Done. Migrated %s from version %s to %s\n", *file, migration.From, migration.To)
+	return ExitOK
+}
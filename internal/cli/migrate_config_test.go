@@ -0,0 +1,113 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// legacyTeapotYAML is a teapot.yml in version 1.0, with a comment to keep
+const legacyTeapotYAML = `version: "1.0"
+project:
+    name: legacy
+# Workspace layout
+architecture: turborepo
+applications:
+    - id: app-react
+      name: web
+      type: react
+devTools:
+    linting: biome
+    typescript: true
+`
+
+// writeLegacyTeapotYAML writes a version 1.0 teapot.yml and returns its path
+func writeLegacyTeapotYAML(t *testing.T) string {
+	t.Helper()
+
+	path := filepath.Join(t.TempDir(), "teapot.yml")
+	if err := os.WriteFile(path, []byte(legacyTeapotYAML), 0644); err != nil {
+		t.Fatalf("Failed to write teapot.yml: %v", err)
+	}
+	return path
+}
+
+func TestRunMigrateConfig(t *testing.T) {
+	file := writeLegacyTeapotYAML(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runMigrateConfig([]string{"-f", file, "--dry-run"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if content, _ := os.ReadFile(file); string(content) != legacyTeapotYAML {
+		t.Error("Expected --dry-run to leave teapot.yml unchanged")
+	}
+	if !strings.Contains(stdout.String(), "    # Workspace layout\n    architecture: turborepo") {
+		t.Errorf("Expected the migrated teapot.yml on stdout, got:\n%s", stdout.String())
+	}
+
+	stdout.Reset()
+	if code := runMigrateConfig([]string{"-f", file}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	if !strings.Contains(stdout.String(), "from version 1.0 to 2.0") {
+		t.Errorf("Expected a summary of the migration, got:\n%s", stdout.String())
+	}
+
+	// The migrated file loads without warnings and migrating again is a no-op
+	stdout.Reset()
+	stderr.Reset()
+	if code := runValidate([]string{"-f", file}, &stdout, &stderr); code != ExitOK || strings.Contains(stdout.String(), "warning") {
+		t.Errorf("Expected the migrated file to validate cleanly, got %d:\n%s", code, stdout.String())
+	}
+	stdout.Reset()
+	if code := runMigrateConfig([]string{"-f", file}, &stdout, &stderr); code != ExitOK || !strings.Contains(stdout.String(), "already uses the current version") {
+		t.Errorf("Expected a current file to be left alone, got %d:\n%s", code, stdout.String())
+	}
+}
+
+func TestLegacyTeapotYAMLWarnings(t *testing.T) {
+	file := writeLegacyTeapotYAML(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", file, "--dry-run"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected a 1.0 teapot.yml to load, got %d (stderr: %s)", code, stderr.String())
+	}
+	for _, expected := range []string{
+		"teapot generate: warning: [Deprecation] the top-level architecture setting is deprecated",
+		"run 'teapot migrate-config'",
+	} {
+		if !strings.Contains(stderr.String(), expected) {
+			t.Errorf("Expected stderr to contain %q, got:\n%s", expected, stderr.String())
+		}
+	}
+}
+
+func TestRunMigrateConfig_Errors(t *testing.T) {
+	dir := t.TempDir()
+	unsupported := filepath.Join(dir, "teapot.yml")
+	if err := os.WriteFile(unsupported, []byte("version: \"0.1\"\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "missing file", args: []string{"-f", filepath.Join(dir, "missing.yml")}, want: ExitInvalidConfig},
+		{name: "unsupported version", args: []string{"-f", unsupported}, want: ExitInvalidConfig},
+		{name: "unexpected argument", args: []string{"extra"}, want: ExitUsage},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runMigrateConfig(tt.args, &stdout, &stderr); code != tt.want {
+				t.Errorf("Expected exit code %d, got %d (stderr: %s)", tt.want, code, stderr.String())
+			}
+		})
+	}
+}
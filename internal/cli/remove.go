@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// confirmInput is where confirmation answers are read from.
+// It is a variable so tests can answer without a terminal.
+var confirmInput io.Reader = os.Stdin
+
+// runRemove removes from the project described by teapot.yml. Only applications are supported.
+func runRemove(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("remove", stderr)
+	file := fileFlag(fs)
+	var yes, dryRun bool
+	fs.BoolVar(&yes, "y", false, "remove without asking for confirmation")
+	fs.BoolVar(&yes, "yes", false, "remove without asking for confirmation")
+	fs.BoolVar(&dryRun, "dry-run", false, "list the affected files without changing anything")
+	positional, ok, code := parseFlags(fs, args, 2)
+	if !ok {
+		return code
+	}
+
+	if len(positional) < 2 {
+		fmt.Fprintln(stderr, "teapot remove: missing what to remove")
+		fs.Usage()
+		return ExitUsage
+	}
+	if positional[0] != "app" {
+		fmt.Fprintf(stderr, "teapot remove: cannot remove %q (supported: app)\n", positional[0])
+		return ExitUsage
+	}
+
+	project, err := loadTeapotYAML("remove", *file, stderr)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot remove: %v\n", err)
+		return ExitInvalidConfig
+	}
+	index, err := generator.FindApplication(project, positional[1])
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot remove: %v\n", err)
+		return ExitUsage
+	}
+
+	// Regenerating without the application drops its references from the
+	// workspace, CI, compose and teapot.yml files like an upgrade does
+	removal, err := generator.PlanRemoval(filepath.Dir(*file), project, index)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot remove: %v\n", err)
+		return ExitFailure
+	}
+
+	app := removal.App
+	fmt.Fprintf(stdout, "Removing %s (%s) from %s affects these files:\n", app.Name, models.AppTypeNames[app.Type], project.Name)
+	for _, path := range removal.Deleted {
+		fmt.Fprintf(stdout, "  - %s (deleted)\n", path)
+	}
+	printUpgradeReport(stdout, removal.Upgrade)
+
+	if dryRun {
+		fmt.Fprintln(stdout, "Dry run: nothing was written")
+		return ExitOK
+	}
+	if !yes && !confirm(stdout, fmt.Sprintf("Remove %s and delete %s?", app.Name, removal.Dir)) {
+		fmt.Fprintln(stderr, "teapot remove: cancelled, nothing was changed")
+		return ExitFailure
+	}
+
+	if err := removal.Apply(); err != nil {
+		fmt.Fprintf(stderr, "teapot remove: %v\n", err)
+		return ExitFailure
+	}
+	if !checkConflicts(removal.Upgrade, stderr) {
+		return ExitFailure
+	}
+
+	fmt.Fprintf(stdout, "Done. Removed %s from %s\n", app.Name, project.Name)
+	return ExitOK
+}
+
+// confirm asks a yes/no question on stdout and reports whether it was answered with yes
+func confirm(stdout io.Writer, question string) bool {
+	fmt.Fprintf(stdout, "%s [y/N] ", question)
+	answer, _ := bufio.NewReader(confirmInput).ReadString('\n')
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "y", "yes":
+		return true
+	default:
+		return false
+	}
+}
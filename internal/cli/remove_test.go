@@ -0,0 +1,102 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"teapot/internal/generator"
+)
+
+// answerConfirmation feeds answer to the next confirmation prompt
+func answerConfirmation(t *testing.T, answer string) {
+	t.Helper()
+
+	original := confirmInput
+	confirmInput = strings.NewReader(answer)
+	t.Cleanup(func() { confirmInput = original })
+}
+
+// generateProjectWithMobile generates a project and adds an Expo application to it
+func generateProjectWithMobile(t *testing.T) string {
+	t.Helper()
+
+	file := generateProject(t)
+	var stdout, stderr bytes.Buffer
+	if code := runAdd([]string{"app", "-f", file, "--app", "expo:mobile"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected the application to be added, got %d (stderr: %s)", code, stderr.String())
+	}
+	return file
+}
+
+func TestRunRemove_App(t *testing.T) {
+	file := generateProjectWithMobile(t)
+	root := filepath.Dir(file)
+
+	answerConfirmation(t, "y\n")
+	var stdout, stderr bytes.Buffer
+	if code := runRemove([]string{"app", "mobile", "-f", file}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	if _, err := os.Stat(filepath.Join(root, "apps", "mobile")); !os.IsNotExist(err) {
+		t.Error("Expected the application folder to be deleted")
+	}
+	project, err := generator.LoadTeapotYAML(file)
+	if err != nil {
+		t.Fatalf("Expected teapot.yml to load, got error: %v", err)
+	}
+	if len(project.Applications) != 1 || project.Applications[0].Name != "web" {
+		t.Errorf("Expected only the web application to remain, got %v", project.Applications)
+	}
+	for _, line := range []string{
+		"Removing mobile (Expo) from ci-project affects these files:",
+		"  - apps/mobile/package.json (deleted)",
+		"  ~ teapot.yml (updated)",
+		"Remove mobile and delete apps/mobile? [y/N]",
+		"Done. Removed mobile from ci-project",
+	} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, stdout.String())
+		}
+	}
+}
+
+func TestRunRemove_Declined(t *testing.T) {
+	file := generateProjectWithMobile(t)
+
+	for _, args := range [][]string{{"app", "app-expo", "-f", file}, {"app", "mobile", "-f", file, "--dry-run"}} {
+		answerConfirmation(t, "\n")
+		var stdout, stderr bytes.Buffer
+		runRemove(args, &stdout, &stderr)
+		if _, err := os.Stat(filepath.Join(filepath.Dir(file), "apps", "mobile", "package.json")); err != nil {
+			t.Fatalf("Expected %v to leave the application in place: %v", args, err)
+		}
+	}
+}
+
+func TestRunRemove_Errors(t *testing.T) {
+	file := generateProject(t)
+
+	tests := []struct {
+		name string
+		args []string
+		want int
+	}{
+		{name: "missing application", args: []string{"app"}, want: ExitUsage},
+		{name: "unknown kind", args: []string{"package", "ui"}, want: ExitUsage},
+		{name: "unknown application", args: []string{"app", "admin", "-f", file}, want: ExitUsage},
+		{name: "missing teapot.yml", args: []string{"app", "web", "-f", filepath.Join(t.TempDir(), "teapot.yml")}, want: ExitInvalidConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := runRemove(tt.args, &stdout, &stderr); code != tt.want {
+				t.Errorf("Expected exit code %d, got %d (stderr: %s)", tt.want, code, stderr.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,179 @@
+package cli
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+
+	errs "teapot/internal/errors"
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// formatJSON prints one JSON event per line (NDJSON) for tools wrapping teapot
+const formatJSON = "json"
+
+// Events printed with --output json
+const (
+	eventStarted       = "started"
+	eventStepStarted   = "step_started"
+	eventStepFinished  = "step_finished"
+	eventFileWritten   = "file_written"
+	eventFileSkipped   = "file_skipped"
+	eventFilePlanned   = "file_planned"
+	eventBackupCreated = "backup_created"
+	eventCheck         = "check"
+	eventWarning       = "warning"
+	eventError         = "error"
+	eventFinished      = "finished"
+)
+
+// jsonEvent is one line of --output json output. Fields that do not apply to
+// an event are left out.
+type jsonEvent struct {
+	// Event names the kind of event
+	Event string `json:"event"`
+	// Command is the teapot command that emitted the event
+	Command string `json:"command"`
+	// Project is the project name, set on started events
+	Project string `json:"project,omitempty"`
+	// Step is the 1-based step number of step events
+	Step int `json:"step,omitempty"`
+	// Steps is the number of steps in the plan
+	Steps int `json:"steps,omitempty"`
+	// Name is the step or tool name
+	Name string `json:"name,omitempty"`
+	// Path is the file, directory or executable the event is about
+	Path string `json:"path,omitempty"`
+	// Files is the number of files to generate, set on started events
+	Files int `json:"files,omitempty"`
+	// Line is the 1-based line a problem was found at
+	Line int `json:"line,omitempty"`
+	// Column is the 1-based column a problem was found at
+	Column int `json:"column,omitempty"`
+	// Version is the version of a checked tool
+	Version string `json:"version,omitempty"`
+	// Reason tells why a checked tool is required, empty for optional tools
+	Reason string `json:"reason,omitempty"`
+	// Hint tells how to fix a problem
+	Hint string `json:"hint,omitempty"`
+	// Error describes the warning or error of the event
+	Error *jsonError `json:"error,omitempty"`
+	// ExitCode is the process exit code, set on the finished event
+	ExitCode *int `json:"exitCode,omitempty"`
+}
+
+// jsonError is a TeapotError in --output json output
+type jsonError struct {
+	Type           string `json:"type"`
+	Message        string `json:"message"`
+	Recoverable    bool   `json:"recoverable"`
+	RecoveryAction string `json:"recoveryAction"`
+}
+
+// newJSONError converts a TeapotError for --output json output
+func newJSONError(err *errs.TeapotError) *jsonError {
+	return &jsonError{
+		Type:           err.Type.String(),
+		Message:        err.Message,
+		Recoverable:    err.Recoverable,
+		RecoveryAction: err.RecoveryAction,
+	}
+}
+
+// reporter prints what a command does: text for people on stdout and stderr,
+// or with --output json one event per line on stdout.
+type reporter struct {
+	command string
+	stdout  io.Writer
+	stderr  io.Writer
+	// encoder writes the events, nil for text output
+	encoder *json.Encoder
+}
+
+// newReporter creates a reporter for a command in the given output format
+func newReporter(command, format string, stdout, stderr io.Writer) *reporter {
+	r := &reporter{command: command, stdout: stdout, stderr: stderr}
+	if format == formatJSON {
+		r.encoder = json.NewEncoder(stdout)
+		r.encoder.SetEscapeHTML(false)
+	}
+	return r
+}
+
+// json reports whether events are printed instead of text
+func (r *reporter) json() bool {
+	return r.encoder != nil
+}
+
+// emit prints an event; it does nothing for text output
+func (r *reporter) emit(event jsonEvent) {
+	if r.encoder == nil {
+		return
+	}
+	event.Command = r.command
+	r.encoder.Encode(event)
+}
+
+// printf prints a line of text output; it does nothing for JSON output
+func (r *reporter) printf(format string, args ...interface{}) {
+	if r.encoder == nil {
+		fmt.Fprintf(r.stdout, format, args...)
+	}
+}
+
+// warning reports a problem that does not stop the command
+func (r *reporter) warning(err *errs.TeapotError) {
+	if r.encoder == nil {
+		fmt.Fprintf(r.stderr, "teapot %s: warning: %v\n", r.command, err)
+		return
+	}
+	r.emit(jsonEvent{Event: eventWarning, Error: newJSONError(err)})
+}
+
+// fail reports the error that stops the command. Errors that are not
+// TeapotErrors are reported with errType.
+func (r *reporter) fail(err error, errType errs.ErrorType) {
+	if r.encoder == nil {
+		fmt.Fprintf(r.stderr, "teapot %s: %v\n", r.command, err)
+		return
+	}
+	r.emit(jsonEvent{Event: eventError, Error: newJSONError(errs.AsTeapotError(err, errType))})
+}
+
+// finish ends JSON output with the exit code and returns it
+func (r *reporter) finish(code int) int {
+	r.emit(jsonEvent{Event: eventFinished, ExitCode: &code})
+	return code
+}
+
+// loadTeapotYAML loads a teapot.yml file and reports its deprecation warnings
+func (r *reporter) loadTeapotYAML(file string) (models.ProjectConfig, error) {
+	project, warnings, err := generator.LoadTeapotYAMLWithWarnings(file)
+	for _, warning := range warnings {
+		r.warning(warning)
+	}
+	return project, err
+}
+
+// outputFlag registers the --output flag that selects the output format, with
+// --format as an alias
+func outputFlag(fs *flag.FlagSet, usage string) *string {
+	format := new(string)
+	fs.StringVar(format, "output", formatText, usage)
+	fs.StringVar(format, "format", formatText, "alias for --output")
+	return format
+}
+
+// parseFormat checks the --output value of a command against the formats it supports
+func parseFormat(command, format string, stderr io.Writer, formats ...string) bool {
+	for _, supported := range formats {
+		if format == supported {
+			return true
+		}
+	}
+	fmt.Fprintf(stderr, "teapot %s: invalid --output %q (choose from %s)\n", command, format, strings.Join(formats, ", "))
+	return false
+}
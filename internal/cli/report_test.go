@@ -0,0 +1,36 @@
+package cli
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+// decodeEvents parses --output json output, failing the test on any line that is not an event
+func decodeEvents(t *testing.T, output string) []jsonEvent {
+	t.Helper()
+
+	var events []jsonEvent
+	for _, line := range strings.Split(strings.TrimSuffix(output, "\n"), "\n") {
+		var event jsonEvent
+		if err := json.Unmarshal([]byte(line), &event); err != nil {
+			t.Fatalf("Expected one JSON event per line, got %q: %v", line, err)
+		}
+		events = append(events, event)
+	}
+	if len(events) == 0 || events[len(events)-1].Event != eventFinished || events[len(events)-1].ExitCode == nil {
+		t.Fatalf("Expected the output to end with a finished event, got:\n%s", output)
+	}
+	return events
+}
+
+// findEvents returns the events of the given kind
+func findEvents(events []jsonEvent, kind string) []jsonEvent {
+	var found []jsonEvent
+	for _, event := range events {
+		if event.Event == kind {
+			found = append(found, event)
+		}
+	}
+	return found
+}
@@ -0,0 +1,65 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+	"teapot/internal/ui/screens"
+)
+
+// schemaChoices collects the choices the wizard offers, which both the JSON
+// Schema and teapot validate check teapot.yml against
+func schemaChoices() generator.SchemaChoices {
+	ciFeatures, _ := screens.CIFeatures()
+	choices := generator.SchemaChoices{
+		Architectures:   architectureKeys(),
+		PackageManagers: screens.PackageManagers(),
+		AppTypes:        appTypeKeys(),
+		Packages:        screens.SharedPackages(),
+		LintingTools:    screens.LintingTools(),
+		CIProviders:     screens.CIProviders(),
+		CIFeatures:      ciFeatures,
+		AITools:         screens.AITools(),
+		AppOptions:      make(map[string][]generator.SchemaOption),
+	}
+	for _, appType := range choices.AppTypes {
+		for _, option := range screens.AppOptions(models.AppType(appType)) {
+			choices.AppOptions[appType] = append(choices.AppOptions[appType], generator.SchemaOption{
+				Key:         option.Key,
+				Description: option.Name + ": " + option.Description,
+			})
+		}
+	}
+	return choices
+}
+
+// runSchema prints the JSON Schema of teapot.yml for editors and other tooling
+func runSchema(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("schema", stderr)
+	var output string
+	fs.StringVar(&output, "o", "", "write the schema to `file` instead of stdout")
+	fs.StringVar(&output, "output", "", "write the schema to `file` instead of stdout")
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+
+	schema, err := generator.TeapotYAMLSchema(schemaChoices())
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot schema: %v\n", err)
+		return ExitFailure
+	}
+
+	if output == "" {
+		stdout.Write(schema)
+		return ExitOK
+	}
+	if err := os.WriteFile(output, schema, 0644); err != nil {
+		fmt.Fprintf(stderr, "teapot schema: failed to write schema: %v\n", err)
+		return ExitFailure
+	}
+	fmt.Fprintf(stdout, "Wrote the teapot.yml schema to %s\n", output)
+	return ExitOK
+}
@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"teapot/internal/models"
+	"teapot/internal/ui/screens"
+)
+
+func TestRunSchema(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runSchema(nil, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+
+	var schema struct {
+		Properties struct {
+			Applications struct {
+				Items struct {
+					AllOf []struct {
+						If struct {
+							Properties struct {
+								Type struct {
+									Const string `json:"const"`
+								} `json:"type"`
+							} `json:"properties"`
+						} `json:"if"`
+						Then struct {
+							Properties struct {
+								Options struct {
+									Properties map[string]interface{} `json:"properties"`
+								} `json:"options"`
+							} `json:"properties"`
+						} `json:"then"`
+					} `json:"allOf"`
+				} `json:"items"`
+			} `json:"applications"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(stdout.Bytes(), &schema); err != nil {
+		t.Fatalf("Expected JSON on stdout, got error: %v", err)
+	}
+
+	// Every option the wizard writes must be known to the schema
+	rules := schema.Properties.Applications.Items.AllOf
+	if len(rules) != len(appTypeKeys()) {
+		t.Fatalf("Expected option rules for %d application types, got %d", len(appTypeKeys()), len(rules))
+	}
+	for _, rule := range rules {
+		appType := models.AppType(rule.If.Properties.Type.Const)
+		for key := range screens.DefaultAppOptions(appType) {
+			if _, ok := rule.Then.Properties.Options.Properties[key]; !ok {
+				t.Errorf("Expected the schema to describe the %s option %q", appType, key)
+			}
+		}
+	}
+}
+
+func TestRunSchema_Output(t *testing.T) {
+	output := filepath.Join(t.TempDir(), "teapot.schema.json")
+
+	var stdout, stderr bytes.Buffer
+	if code := runSchema([]string{"-o", output}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitOK, code, stderr.String())
+	}
+	data, err := os.ReadFile(output)
+	if err != nil || !json.Valid(data) {
+		t.Errorf("Expected the schema to be written to %s (error: %v)", output, err)
+	}
+}
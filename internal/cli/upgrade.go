@@ -0,0 +1,99 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"path/filepath"
+
+	"teapot/internal/generator"
+)
+
+// upgradeMarkers are the report prefixes for every status an upgrade writes or reports
+var upgradeMarkers = map[generator.UpgradeStatus]string{
+	generator.UpgradeAdded:    "+",
+	generator.UpgradeUpdated:  "~",
+	generator.UpgradeKept:     "=",
+	generator.UpgradeMerged:   "M",
+	generator.UpgradeConflict: "!",
+	generator.UpgradeSkipped:  "-",
+	generator.UpgradeReplaced: "R",
+}
+
+// runUpgrade re-renders the templates of the project described by teapot.yml and
+// merges template changes into the files next to it
+func runUpgrade(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("upgrade", stderr)
+	file := fileFlag(fs)
+	var dryRun bool
+	fs.BoolVar(&dryRun, "dry-run", false, "report what would change without writing anything")
+
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+
+	project, _, err := loadProject(newReporter("upgrade", formatText, stdout, stderr), *file)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot upgrade: %v\n", err)
+		return ExitInvalidConfig
+	}
+
+	root := filepath.Dir(*file)
+	upgrade, err := generator.PlanUpgrade(root, project)
+	if err != nil {
+		fmt.Fprintf(stderr, "teapot upgrade: %v\n", err)
+		return ExitFailure
+	}
+
+	printUpgradeReport(stdout, upgrade)
+
+	if dryRun {
+		fmt.Fprintln(stdout, "Dry run: nothing was written")
+		return ExitOK
+	}
+	if err := upgrade.Apply(); err != nil {
+		fmt.Fprintf(stderr, "teapot upgrade: %v\n", err)
+		return ExitFailure
+	}
+
+	if !checkConflicts(upgrade, stderr) {
+		return ExitFailure
+	}
+	fmt.Fprintf(stdout, "Done. %s is up to date with the templates\n", project.Name)
+	return ExitOK
+}
+
+// printUpgradeReport prints one line per file an upgrade touches and a summary
+func printUpgradeReport(stdout io.Writer, upgrade *generator.Upgrade) {
+	for _, result := range upgrade.Files {
+		marker, ok := upgradeMarkers[result.Status]
+		if !ok {
+			continue
+		}
+		if result.Status == generator.UpgradeConflict {
+			fmt.Fprintf(stdout, "  %s %s (%d conflicting hunks)\n", marker, result.Path, result.Conflicts)
+		} else {
+			fmt.Fprintf(stdout, "  %s %s (%s)\n", marker, result.Path, result.Status)
+		}
+	}
+	for _, path := range upgrade.Orphaned {
+		fmt.Fprintf(stdout, "  ? %s (no longer generated, left alone)\n", path)
+	}
+
+	fmt.Fprintf(stdout, "%d added, %d updated, %d merged, %d conflicts, %d kept local changes\n",
+		upgrade.Count(generator.UpgradeAdded), upgrade.Count(generator.UpgradeUpdated),
+		upgrade.Count(generator.UpgradeMerged), upgrade.Count(generator.UpgradeConflict), upgrade.Count(generator.UpgradeKept))
+	if upgrade.Backup != "" {
+		fmt.Fprintf(stdout, "%d replaced; the project has no recorded generated content to merge with, so the previous files are kept in %s\n",
+			upgrade.Count(generator.UpgradeReplaced), upgrade.Backup)
+	}
+}
+
+// checkConflicts reports whether an applied upgrade left no conflict markers behind
+func checkConflicts(upgrade *generator.Upgrade, stderr io.Writer) bool {
+	conflicts := upgrade.Count(generator.UpgradeConflict)
+	if conflicts > 0 {
+		fmt.Fprintf(stderr, "Resolve the conflict markers in %d file(s) before committing\n", conflicts)
+		return false
+	}
+	return true
+}
@@ -0,0 +1,60 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRunUpgrade(t *testing.T) {
+	dir := t.TempDir()
+	output := filepath.Join(dir, "out")
+	var stdout, stderr bytes.Buffer
+	if code := runGenerate([]string{"-f", writeTeapotYAML(t, dir), "--dir", output}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected generation to succeed, got %d (stderr: %s)", code, stderr.String())
+	}
+
+	// Edit one generated file and delete another
+	readme := filepath.Join(output, "README.md")
+	if err := os.WriteFile(readme, []byte("# Our notes\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(output, "turbo.json")); err != nil {
+		t.Fatal(err)
+	}
+
+	file := filepath.Join(output, "teapot.yml")
+	stdout.Reset()
+	if code := runUpgrade([]string{"-f", file, "--dry-run"}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected dry run to succeed, got %d (stderr: %s)", code, stderr.String())
+	}
+	for _, line := range []string{"  = README.md (kept local changes)", "  - turbo.json (deleted locally, not restored)", "Dry run"} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("Expected upgrade report to contain %q, got:\n%s", line, stdout.String())
+		}
+	}
+
+	stdout.Reset()
+	if code := runUpgrade([]string{"-f", file}, &stdout, &stderr); code != ExitOK {
+		t.Fatalf("Expected upgrade to succeed, got %d (stderr: %s)", code, stderr.String())
+	}
+	if content, _ := os.ReadFile(readme); string(content) != "# Our notes\n" {
+		t.Errorf("Expected local edits to be kept, got %q", content)
+	}
+	if !strings.Contains(stdout.String(), "Done.") {
+		t.Errorf("Expected upgrade to finish, got:\n%s", stdout.String())
+	}
+}
+
+func TestRunUpgrade_NotGenerated(t *testing.T) {
+	dir := t.TempDir()
+	var stdout, stderr bytes.Buffer
+	if code := runUpgrade([]string{"-f", writeTeapotYAML(t, dir)}, &stdout, &stderr); code != ExitFailure {
+		t.Errorf("Expected exit code %d, got %d", ExitFailure, code)
+	}
+	if !strings.Contains(stderr.String(), "not an upgradable teapot project") {
+		t.Errorf("Expected a missing generated content error, got: %s", stderr.String())
+	}
+}
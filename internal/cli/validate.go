@@ -0,0 +1,123 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	errs "teapot/internal/errors"
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// Output formats of teapot validate
+const (
+	// formatText prints compiler-style diagnostics for people
+	formatText = "text"
+	// formatGitHub prints GitHub Actions workflow commands, which CI shows as annotations
+	formatGitHub = "github"
+)
+
+// runValidate checks teapot.yml without generating anything and reports every
+// problem with its line and column
+func runValidate(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("validate", stderr)
+	file := fileFlag(fs)
+	output := outputFlag(fs, "output `format`: "+formatText+", "+formatGitHub+" (CI annotations) or "+formatJSON+" (one event per line)")
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+	format := *output
+	if !parseFormat("validate", format, stderr, formatText, formatGitHub, formatJSON) {
+		return ExitUsage
+	}
+
+	r := newReporter("validate", format, stdout, stderr)
+	data, err := os.ReadFile(*file)
+	if err != nil {
+		r.fail(fmt.Errorf("failed to read YAML file: %w", err), errs.ErrorTypeSystem)
+		return r.finish(ExitInvalidConfig)
+	}
+
+	diagnostics := generator.ValidateTeapotYAML(data, schemaChoices())
+	errorCount := 0
+	for _, diagnostic := range diagnostics {
+		if !diagnostic.IsWarning() {
+			errorCount++
+		}
+		switch format {
+		case formatGitHub:
+			printAnnotation(stdout, *file, diagnostic)
+		case formatJSON:
+			r.emit(jsonEvent{
+				Event:  diagnostic.Severity(),
+				Path:   *file,
+				Line:   diagnostic.Line,
+				Column: diagnostic.Column,
+				Hint:   diagnostic.Suggestion,
+				Error:  newJSONError(diagnostic.Err),
+			})
+		default:
+			printDiagnostic(stdout, *file, diagnostic)
+		}
+	}
+
+	if errorCount > 0 {
+		if format == formatText {
+			fmt.Fprintf(stdout, "✗ %s has %d %s\n", *file, errorCount, plural(errorCount, "error", "errors"))
+		}
+		return r.finish(ExitInvalidConfig)
+	}
+
+	project, err := generator.ParseTeapotYAML(data)
+	if err == nil {
+		var plan *generator.Plan
+		if plan, err = generator.BuildPlan(project); err == nil && format == formatText {
+			fmt.Fprintf(stdout, "✓ %s is valid: %s (%s, %d applications, %d files)\n",
+				*file, project.Name, models.ArchitectureNames[project.Architecture], len(project.Applications), plan.FileCount())
+		}
+	}
+	if err != nil {
+		// The diagnostics should have caught this; never report an invalid file as valid
+		r.fail(fmt.Errorf("%s: %w", *file, err), errs.ErrorTypeValidation)
+		return r.finish(ExitInvalidConfig)
+	}
+	return r.finish(ExitOK)
+}
+
+// printDiagnostic prints a diagnostic as file:line:column: severity: message
+func printDiagnostic(w io.Writer, file string, diagnostic generator.Diagnostic) {
+	fmt.Fprintf(w, "%s:%d:%d: %s: %s\n", file, diagnostic.Line, diagnostic.Column, diagnostic.Severity(), diagnostic.Err.Message)
+	if diagnostic.Suggestion != "" {
+		fmt.Fprintf(w, "    hint: %s\n", diagnostic.Suggestion)
+	}
+}
+
+// printAnnotation prints a diagnostic as a GitHub Actions workflow command
+func printAnnotation(w io.Writer, file string, diagnostic generator.Diagnostic) {
+	message := diagnostic.Err.Message
+	if diagnostic.Suggestion != "" {
+		message += " (" + diagnostic.Suggestion + ")"
+	}
+	fmt.Fprintf(w, "::%s file=%s,line=%d,col=%d,title=teapot validate::%s\n",
+		diagnostic.Severity(), escapeAnnotationProperty(file), diagnostic.Line, diagnostic.Column, escapeAnnotationData(message))
+}
+
+// escapeAnnotationData escapes the message of a workflow command
+func escapeAnnotationData(value string) string {
+	return strings.NewReplacer("%", "%25", "\r", "%0D", "\n", "%0A").Replace(value)
+}
+
+// escapeAnnotationProperty escapes a property value of a workflow command
+func escapeAnnotationProperty(value string) string {
+	return strings.NewReplacer(":", "%3A", ",", "%2C").Replace(escapeAnnotationData(value))
+}
+
+// plural returns singular for one and plural otherwise
+func plural(count int, singular, plural string) string {
+	if count == 1 {
+		return singular
+	}
+	return plural
+}
@@ -0,0 +1,108 @@
+package cli
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeInvalidTeapotYAML writes a teapot.yml with an invalid project name and linting setup
+func writeInvalidTeapotYAML(t *testing.T) string {
+	t.Helper()
+
+	path := writeTeapotYAML(t, t.TempDir())
+	content, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	invalid := strings.NewReplacer("name: ci-project", "name: ci/project", "linting: biome", "linting: biom").Replace(string(content))
+	if err := os.WriteFile(path, []byte(invalid), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+func TestRunValidate_Diagnostics(t *testing.T) {
+	file := writeInvalidTeapotYAML(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"-f", file}, &stdout, &stderr); code != ExitInvalidConfig {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitInvalidConfig, code, stderr.String())
+	}
+	for _, line := range []string{
+		file + ":3:11: error: project name cannot contain path separators\n    hint: use \"ciproject\"\n",
+		": error: devTools.linting: unknown value \"biom\"\n    hint: did you mean \"biome\"?\n",
+		"✗ " + file + " has 2 errors\n",
+	} {
+		if !strings.Contains(stdout.String(), line) {
+			t.Errorf("Expected output to contain %q, got:\n%s", line, stdout.String())
+		}
+	}
+}
+
+func TestRunValidate_GitHubFormat(t *testing.T) {
+	file := writeInvalidTeapotYAML(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"-f", file, "--output", "github"}, &stdout, &stderr); code != ExitInvalidConfig {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitInvalidConfig, code, stderr.String())
+	}
+
+	lines := strings.Split(strings.TrimSpace(stdout.String()), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("Expected one annotation per problem, got:\n%s", stdout.String())
+	}
+	expected := "::error file=" + escapeAnnotationProperty(file) + ",line=3,col=11,title=teapot validate::project name cannot contain path separators (use \"ciproject\")"
+	if lines[0] != expected {
+		t.Errorf("Expected annotation\n%s\ngot\n%s", expected, lines[0])
+	}
+
+	// A valid file prints no annotations
+	stdout.Reset()
+	if code := runValidate([]string{"-f", writeTeapotYAML(t, t.TempDir()), "--format", "github"}, &stdout, &stderr); code != ExitOK || stdout.Len() != 0 {
+		t.Errorf("Expected a valid file to pass silently, got %d:\n%s", code, stdout.String())
+	}
+}
+
+func TestRunValidate_InvalidFormat(t *testing.T) {
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"--format", "xml", "-f", filepath.Join(t.TempDir(), "teapot.yml")}, &stdout, &stderr); code != ExitUsage {
+		t.Errorf("Expected exit code %d, got %d", ExitUsage, code)
+	}
+}
+
+func TestEscapeAnnotation(t *testing.T) {
+	if got := escapeAnnotationData("50% done\nnext"); got != "50%25 done%0Anext" {
+		t.Errorf("Expected escaped data, got %q", got)
+	}
+	if got := escapeAnnotationProperty("C:\\a,b"); got != "C%3A\\a%2Cb" {
+		t.Errorf("Expected escaped property, got %q", got)
+	}
+}
+
+func TestRunValidate_JSONFormat(t *testing.T) {
+	file := writeInvalidTeapotYAML(t)
+
+	var stdout, stderr bytes.Buffer
+	if code := runValidate([]string{"-f", file, "--output", "json"}, &stdout, &stderr); code != ExitInvalidConfig {
+		t.Fatalf("Expected exit code %d, got %d (stderr: %s)", ExitInvalidConfig, code, stderr.String())
+	}
+
+	events := decodeEvents(t, stdout.String())
+	problems := findEvents(events, eventError)
+	if len(problems) != 2 {
+		t.Fatalf("Expected 2 error events, got:\n%s", stdout.String())
+	}
+	first := problems[0]
+	if first.Path != file || first.Line != 3 || first.Column != 11 || first.Hint != `use "ciproject"` {
+		t.Errorf("Expected the position and hint of the invalid name, got %+v", first)
+	}
+	if first.Error.Type != "Validation" || !first.Error.Recoverable || first.Error.RecoveryAction == "" {
+		t.Errorf("Expected a recoverable validation error, got %+v", first.Error)
+	}
+	if code := *events[len(events)-1].ExitCode; code != ExitInvalidConfig {
+		t.Errorf("Expected the finished event to carry exit code %d, got %d", ExitInvalidConfig, code)
+	}
+}
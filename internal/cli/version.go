@@ -0,0 +1,21 @@
+package cli
+
+import (
+	"fmt"
+	"io"
+)
+
+// Version is the Teapot release, set at build time with
+// -ldflags "-X teapot/internal/cli.Version=<version>".
+var Version = "dev"
+
+// runVersion prints the version
+func runVersion(args []string, stdout, stderr io.Writer) int {
+	fs := newFlagSet("version", stderr)
+	if _, ok, code := parseFlags(fs, args, 0); !ok {
+		return code
+	}
+
+	fmt.Fprintf(stdout, "teapot %s\n", Version)
+	return ExitOK
+}
@@ -4,6 +4,7 @@
 package errors
 
 import (
+	stderrors "errors"
 	"fmt"
 	"os"
 	"runtime"
@@ -28,6 +29,8 @@ const (
 	ErrorTypeSystem
 	// ErrorTypePanic represents recovered panic errors
 	ErrorTypePanic
+	// ErrorTypeDeprecation represents warnings about deprecated configuration
+	ErrorTypeDeprecation
 )
 
 // String returns the string representation of an ErrorType
@@ -43,6 +46,8 @@ func (et ErrorType) String() string {
 		return "System"
 	case ErrorTypePanic:
 		return "Panic"
+	case ErrorTypeDeprecation:
+		return "Deprecation"
 	default:
 		return "Unknown"
 	}
@@ -89,6 +94,19 @@ func NewTeapotError(errType ErrorType, message string, cause error) *TeapotError
 	}
 }
 
+// AsTeapotError returns err as a TeapotError. A TeapotError is returned as is,
+// errors wrapping one keep its type, and other errors get errType.
+func AsTeapotError(err error, errType ErrorType) *TeapotError {
+	var teapotErr *TeapotError
+	if stderrors.As(err, &teapotErr) {
+		if teapotErr == err {
+			return teapotErr
+		}
+		errType = teapotErr.Type
+	}
+	return NewTeapotError(errType, err.Error(), err)
+}
+
 // ErrorRecovery provides error recovery mechanisms for the application
 type ErrorRecovery struct {
 	// errorLog stores recent errors for debugging
@@ -314,7 +332,7 @@ func getErrorDetails(err error) string {
 // isRecoverable determines if an error type can be recovered from
 func isRecoverable(errType ErrorType) bool {
 	switch errType {
-	case ErrorTypeValidation, ErrorTypeNavigation, ErrorTypeUI:
+	case ErrorTypeValidation, ErrorTypeNavigation, ErrorTypeUI, ErrorTypeDeprecation:
 		return true
 	case ErrorTypePanic:
 		return true // Most panics in UI can be recovered
@@ -338,6 +356,8 @@ func getRecoveryAction(errType ErrorType) string {
 		return "Reset to safe state"
 	case ErrorTypeSystem:
 		return "Exit gracefully"
+	case ErrorTypeDeprecation:
+		return "Warn and continue"
 	default:
 		return "Show error and continue"
 	}
@@ -363,4 +383,9 @@ func NewUIError(message string, cause error) *TeapotError {
 // NewSystemError creates a system error
 func NewSystemError(message string, cause error) *TeapotError {
 	return NewTeapotError(ErrorTypeSystem, message, cause)
-}
\ No newline at end of file
+}
+
+// NewDeprecationError creates a deprecation warning
+func NewDeprecationError(message string, cause error) *TeapotError {
+	return NewTeapotError(ErrorTypeDeprecation, message, cause)
+}
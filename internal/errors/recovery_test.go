@@ -41,6 +41,37 @@ func TestTeapotError_Error(t *testing.T) {
 	}
 }
 
+func TestAsTeapotError(t *testing.T) {
+	system := NewSystemError("disk full", nil)
+
+	tests := []struct {
+		name        string
+		err         error
+		wantType    ErrorType
+		wantMessage string
+	}{
+		{name: "teapot error", err: system, wantType: ErrorTypeSystem, wantMessage: "disk full"},
+		{name: "wrapped teapot error", err: fmt.Errorf("step failed: %w", system), wantType: ErrorTypeSystem, wantMessage: "step failed: [System] disk full"},
+		{name: "plain error", err: fmt.Errorf("bad value"), wantType: ErrorTypeValidation, wantMessage: "bad value"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := AsTeapotError(tt.err, ErrorTypeValidation)
+			if err.Type != tt.wantType || err.Message != tt.wantMessage {
+				t.Errorf("Expected [%s] %s, got %v", tt.wantType, tt.wantMessage, err)
+			}
+			if err.RecoveryAction == "" {
+				t.Error("Expected a recovery action")
+			}
+		})
+	}
+
+	if AsTeapotError(system, ErrorTypeValidation) != system {
+		t.Error("Expected a TeapotError to be returned as is")
+	}
+}
+
 func TestErrorType_String(t *testing.T) {
 	tests := []struct {
 		errType  ErrorType
@@ -51,6 +82,7 @@ func TestErrorType_String(t *testing.T) {
 		{ErrorTypeUI, "UI"},
 		{ErrorTypeSystem, "System"},
 		{ErrorTypePanic, "Panic"},
+		{ErrorTypeDeprecation, "Deprecation"},
 		{ErrorTypeUnknown, "Unknown"},
 	}
 	
@@ -215,6 +247,7 @@ func TestIsRecoverable(t *testing.T) {
 		{ErrorTypeUI, true},
 		{ErrorTypePanic, true},
 		{ErrorTypeSystem, false},
+		{ErrorTypeDeprecation, true},
 		{ErrorTypeUnknown, false},
 	}
 	
@@ -236,6 +269,7 @@ func TestGetRecoveryAction(t *testing.T) {
 		{ErrorTypeUI, "Refresh current screen"},
 		{ErrorTypePanic, "Reset to safe state"},
 		{ErrorTypeSystem, "Exit gracefully"},
+		{ErrorTypeDeprecation, "Warn and continue"},
 		{ErrorTypeUnknown, "Show error and continue"},
 	}
 	
@@ -271,4 +305,10 @@ func TestHelperFunctions(t *testing.T) {
 	if err.Type != ErrorTypeSystem {
 		t.Errorf("Expected system error type, got %v", err.Type)
 	}
+
+	// Test NewDeprecationError
+	err = NewDeprecationError("option deprecated", nil)
+	if err.Type != ErrorTypeDeprecation {
+		t.Errorf("Expected deprecation error type, got %v", err.Type)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,13 @@
+package generator
+
+import "teapot/internal/models"
+
+// PlanAddApplication plans adding app to the project in root. Only the files
+// the application changes are written: its own folder and the workspace, CI,
+// compose and teapot.yml files that list applications. Nothing is written
+// until Apply is called.
+func PlanAddApplication(root string, project models.ProjectConfig, app models.Application) (*Upgrade, error) {
+	updated := project
+	updated.Applications = append(append([]models.Application{}, project.Applications...), app)
+	return planChange(root, project, updated)
+}
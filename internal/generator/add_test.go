@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+func TestPlanAddApplication(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "demo")
+	if err := NewEngine(testProject(), root).Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+	writeProjectFile(t, root, "apps/web/package.json", readProjectFile(t, root, "apps/web/package.json")+"local\n")
+	writeProjectFile(t, root, "README.md", readProjectFile(t, root, "README.md")+"local notes\n")
+
+	app := models.Application{ID: "app-react", Name: "admin", Type: models.AppTypeReact, Options: map[string]interface{}{}}
+	upgrade, err := PlanAddApplication(root, testProject(), app)
+	if err != nil {
+		t.Fatalf("Expected adding an application to plan, got error: %v", err)
+	}
+
+	statuses := make(map[string]UpgradeStatus)
+	for _, file := range upgrade.Files {
+		statuses[file.Path] = file.Status
+	}
+	for _, path := range []string{"apps/admin/package.json", "teapot.yml", "turbo.json", "docker-compose.yml"} {
+		if _, ok := statuses[path]; !ok {
+			t.Errorf("Expected %s to be planned, got %v", path, statuses)
+		}
+	}
+	for _, path := range []string{"apps/web/package.json", "apps/api/package.json", "package.json", "Dockerfile"} {
+		if _, ok := statuses[path]; ok {
+			t.Errorf("Expected %s not to be touched by adding an application", path)
+		}
+	}
+	if statuses["README.md"] != UpgradeMerged {
+		t.Errorf("Expected README.md to be merged, got %v", statuses["README.md"])
+	}
+	if upgrade.Backup != "" {
+		t.Errorf("Expected no backup with recorded generated content, got %s", upgrade.Backup)
+	}
+
+	if err := upgrade.Apply(); err != nil {
+		t.Fatalf("Expected adding an application to apply, got error: %v", err)
+	}
+	if !strings.HasSuffix(readProjectFile(t, root, "apps/web/package.json"), "local\n") {
+		t.Error("Expected unrelated local edits to be kept")
+	}
+	readme := readProjectFile(t, root, "README.md")
+	if !strings.Contains(readme, "apps/admin") || !strings.Contains(readme, "local notes") {
+		t.Errorf("Expected README.md to list the new application and keep local edits, got:\n%s", readme)
+	}
+
+	base, err := LoadBase(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := base.Files["apps/admin/package.json"]; !ok {
+		t.Error("Expected the new application's files to be recorded as generated content")
+	}
+	if _, ok := base.Files["apps/web/package.json"]; !ok {
+		t.Error("Expected the records of untouched files to be kept")
+	}
+}
+
+func TestPlanAddApplication_WithoutBase(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "demo")
+	if err := NewEngine(testProject(), root).Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+	if err := os.Remove(filepath.Join(root, filepath.FromSlash(BasePath))); err != nil {
+		t.Fatal(err)
+	}
+	writeProjectFile(t, root, "README.md", "local readme\n")
+
+	app := models.Application{ID: "app-react", Name: "admin", Type: models.AppTypeReact, Options: map[string]interface{}{}}
+	upgrade, err := PlanAddApplication(root, testProject(), app)
+	if err != nil {
+		t.Fatalf("Expected adding an application to plan, got error: %v", err)
+	}
+
+	statuses := make(map[string]UpgradeStatus)
+	for _, file := range upgrade.Files {
+		statuses[file.Path] = file.Status
+	}
+	if statuses["README.md"] != UpgradeReplaced {
+		t.Errorf("Expected README.md to be replaced, got %v", statuses["README.md"])
+	}
+	if statuses["apps/admin/package.json"] != UpgradeAdded {
+		t.Errorf("Expected the new application to be added, got %v", statuses["apps/admin/package.json"])
+	}
+	if upgrade.Backup == "" {
+		t.Fatal("Expected a backup folder for replaced files")
+	}
+
+	if err := upgrade.Apply(); err != nil {
+		t.Fatalf("Expected adding an application to apply, got error: %v", err)
+	}
+	if backup := readProjectFile(t, root, upgrade.Backup+"/README.md"); backup != "local readme\n" {
+		t.Errorf("Expected the previous README.md to be backed up, got:\n%s", backup)
+	}
+	if !strings.Contains(readProjectFile(t, root, "README.md"), "apps/admin") {
+		t.Error("Expected README.md to be replaced by the new render")
+	}
+	if _, err := LoadBase(root); err != nil {
+		t.Errorf("Expected the new renders to be recorded, got %v", err)
+	}
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// TargetState describes what already exists at a generation target.
+type TargetState int
+
+const (
+	// TargetMissing means the target directory does not exist yet
+	TargetMissing TargetState = iota
+	// TargetEmpty means the target directory exists but has no entries
+	TargetEmpty
+	// TargetNonEmpty means the target directory already has content
+	TargetNonEmpty
+	// TargetGitRepo means the target directory is a git repository
+	TargetGitRepo
+	// TargetTeapotProject means the target directory was generated by teapot before
+	TargetTeapotProject
+)
+
+// String returns a description that completes "<dir> already exists and is ..."
+func (s TargetState) String() string {
+	switch s {
+	case TargetMissing:
+		return "missing"
+	case TargetEmpty:
+		return "empty"
+	case TargetGitRepo:
+		return "a git repository"
+	case TargetTeapotProject:
+		return "a teapot project"
+	default:
+		return "not empty"
+	}
+}
+
+// HasContent reports whether generating into the target could touch existing files.
+func (s TargetState) HasContent() bool {
+	return s != TargetMissing && s != TargetEmpty
+}
+
+// InspectTarget reports what already exists at dir.
+func InspectTarget(dir string) (TargetState, error) {
+	info, err := os.Stat(dir)
+	if os.IsNotExist(err) {
+		return TargetMissing, nil
+	}
+	if err != nil {
+		return TargetMissing, err
+	}
+	if !info.IsDir() {
+		return TargetMissing, fmt.Errorf("%s exists and is not a directory", dir)
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return TargetMissing, err
+	}
+	if len(entries) == 0 {
+		return TargetEmpty, nil
+	}
+
+	state := TargetNonEmpty
+	for _, entry := range entries {
+		switch entry.Name() {
+		case "teapot.yml", ".teapot":
+			return TargetTeapotProject, nil
+		case ".git":
+			state = TargetGitRepo
+		}
+	}
+	return state, nil
+}
+
+// ConflictPolicy decides how generation treats a target directory that already has content.
+type ConflictPolicy string
+
+const (
+	// ConflictAbort refuses to generate into a directory that already has content
+	ConflictAbort ConflictPolicy = "abort"
+	// ConflictMerge writes only the files that do not exist yet
+	ConflictMerge ConflictPolicy = "merge"
+	// ConflictOverwrite moves the existing directory to a backup before generating
+	ConflictOverwrite ConflictPolicy = "overwrite"
+)
+
+// ConflictPolicies lists the supported policies, safest first.
+var ConflictPolicies = []ConflictPolicy{ConflictAbort, ConflictMerge, ConflictOverwrite}
+
+// ParseConflictPolicy converts a policy name into a ConflictPolicy.
+func ParseConflictPolicy(name string) (ConflictPolicy, error) {
+	for _, policy := range ConflictPolicies {
+		if string(policy) == name {
+			return policy, nil
+		}
+	}
+
+	names := make([]string, len(ConflictPolicies))
+	for i, policy := range ConflictPolicies {
+		names[i] = string(policy)
+	}
+	return "", fmt.Errorf("unknown conflict policy %q (choose from %s)", name, strings.Join(names, ", "))
+}
+
+// backupTarget moves an existing target directory aside and returns the backup path.
+func backupTarget(target string) (string, error) {
+	base := fmt.Sprintf("%s.backup-%s", target, time.Now().Format("20060102-150405"))
+	backup := base
+	for i := 2; ; i++ {
+		if _, err := os.Lstat(backup); os.IsNotExist(err) {
+			break
+		}
+		backup = fmt.Sprintf("%s-%d", base, i)
+	}
+
+	if err := os.Rename(target, backup); err != nil {
+		return "", err
+	}
+	return backup, nil
+}
+
+// pathExists reports whether a slash-separated path exists below root.
+func pathExists(root, path string) bool {
+	_, err := os.Lstat(filepath.Join(root, filepath.FromSlash(path)))
+	return err == nil
+}
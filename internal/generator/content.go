@@ -0,0 +1,573 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"teapot/internal/models"
+)
+
+// rootDevDependencies returns the tooling installed at the workspace root.
+func rootDevDependencies(project models.ProjectConfig) map[string]string {
+	deps := map[string]string{}
+	if project.DevTools.TypeScript {
+		deps["typescript"] = "^5.6.0"
+	}
+	switch project.DevTools.Linting {
+	case "prettier-eslint":
+		deps["prettier"] = "^3.3.0"
+		deps["eslint"] = "^9.12.0"
+		deps["typescript-eslint"] = "^8.8.0"
+	case "biome":
+		deps["@biomejs/biome"] = "^1.9.0"
+	}
+	if project.DevTools.Husky {
+		deps["husky"] = "^9.1.0"
+	}
+	if project.DevTools.LintStaged {
+		deps["lint-staged"] = "^15.2.0"
+	}
+	return deps
+}
+
+// lintStagedConfig returns the lint-staged rules for the selected linting tool.
+func lintStagedConfig(linting string) map[string][]string {
+	switch linting {
+	case "biome":
+		return map[string][]string{
+			"*.{js,jsx,ts,tsx,json}": {"biome check --write --no-errors-on-unmatched"},
+		}
+	case "prettier-eslint":
+		return map[string][]string{
+			"*.{js,jsx,ts,tsx}": {"eslint --fix", "prettier --write"},
+			"*.{json,md,yml}":   {"prettier --write"},
+		}
+	default:
+		return nil
+	}
+}
+
+// lintCommand returns the per-package lint command for the selected linting tool.
+func lintCommand(linting string) string {
+	switch linting {
+	case "prettier-eslint":
+		return "eslint ."
+	case "biome":
+		return "biome check ."
+	default:
+		return "tsc --noEmit"
+	}
+}
+
+// appScripts returns the package.json scripts of an application.
+func appScripts(project models.ProjectConfig, app models.Application) map[string]string {
+	scripts := map[string]string{
+		"lint": lintCommand(project.DevTools.Linting),
+	}
+
+	switch app.Type {
+	case models.AppTypeReact:
+		scripts["dev"] = "vite"
+		scripts["build"] = "tsc -b && vite build"
+		scripts["preview"] = "vite preview"
+		scripts["start"] = "vite preview"
+		scripts["test"] = "vitest run --passWithNoTests"
+	case models.AppTypeNext:
+		scripts["dev"] = "next dev"
+		scripts["build"] = "next build"
+		scripts["start"] = "next start"
+		scripts["test"] = "vitest run --passWithNoTests"
+	case models.AppTypeTanStack:
+		scripts["dev"] = "vinxi dev"
+		scripts["build"] = "vinxi build"
+		scripts["start"] = "vinxi start"
+		scripts["test"] = "vitest run --passWithNoTests"
+	case models.AppTypeExpo:
+		scripts["dev"] = "expo start"
+		scripts["start"] = "expo start"
+		scripts["build"] = "expo export"
+		scripts["android"] = "expo start --android"
+		scripts["ios"] = "expo start --ios"
+		scripts["test"] = "jest --passWithNoTests"
+	case models.AppTypeNest:
+		scripts["dev"] = "nest start --watch"
+		scripts["build"] = "nest build"
+		scripts["start"] = "node dist/main.js"
+		scripts["test"] = "jest --passWithNoTests"
+	case models.AppTypeBasicNode:
+		if appOption(app, "typescript") {
+			scripts["dev"] = "tsx watch src/index.ts"
+			scripts["build"] = "tsc -p tsconfig.json"
+			scripts["start"] = "node dist/index.js"
+		} else {
+			scripts["dev"] = "node --watch src/index.js"
+			scripts["start"] = "node src/index.js"
+			if project.DevTools.Linting == "" || project.DevTools.Linting == "custom" {
+				scripts["lint"] = "node --check src/index.js"
+			}
+		}
+		scripts["test"] = "vitest run --passWithNoTests"
+	}
+	return scripts
+}
+
+// appDependencies returns the runtime dependencies of an application,
+// including those required by its enabled options.
+func appDependencies(app models.Application) map[string]string {
+	deps := map[string]string{}
+	switch app.Type {
+	case models.AppTypeReact:
+		deps["react"] = "^18.3.1"
+		deps["react-dom"] = "^18.3.1"
+	case models.AppTypeNext:
+		deps["next"] = "^14.2.0"
+		deps["react"] = "^18.3.1"
+		deps["react-dom"] = "^18.3.1"
+	case models.AppTypeTanStack:
+		deps["@tanstack/react-router"] = "^1.58.0"
+		deps["@tanstack/start"] = "^1.58.0"
+		deps["react"] = "^18.3.1"
+		deps["react-dom"] = "^18.3.1"
+		deps["vinxi"] = "^0.4.3"
+	case models.AppTypeExpo:
+		deps["expo"] = "~51.0.0"
+		deps["expo-status-bar"] = "~1.12.1"
+		deps["react"] = "18.2.0"
+		deps["react-native"] = "0.74.5"
+	case models.AppTypeNest:
+		deps["@nestjs/common"] = "^10.4.0"
+		deps["@nestjs/core"] = "^10.4.0"
+		deps["@nestjs/platform-express"] = "^10.4.0"
+		deps["reflect-metadata"] = "^0.2.2"
+		deps["rxjs"] = "^7.8.1"
+	}
+
+	for option, optionDeps := range appOptionDependencies[app.Type] {
+		if appOption(app, option) {
+			for name, version := range optionDeps.dependencies {
+				deps[name] = version
+			}
+		}
+	}
+	return deps
+}
+
+// appDevDependencies returns the development dependencies of an application,
+// including those required by its enabled options.
+func appDevDependencies(app models.Application) map[string]string {
+	deps := map[string]string{"typescript": "^5.6.0"}
+
+	switch app.Type {
+	case models.AppTypeReact:
+		deps["vite"] = "^5.4.0"
+		deps["@vitejs/plugin-react"] = "^4.3.0"
+		deps["@types/react"] = "^18.3.0"
+		deps["@types/react-dom"] = "^18.3.0"
+		deps["vitest"] = "^2.1.0"
+	case models.AppTypeNext, models.AppTypeTanStack:
+		deps["@types/node"] = "^22.0.0"
+		deps["@types/react"] = "^18.3.0"
+		deps["@types/react-dom"] = "^18.3.0"
+		deps["vitest"] = "^2.1.0"
+	case models.AppTypeExpo:
+		deps["@babel/core"] = "^7.24.0"
+		deps["@types/react"] = "~18.2.0"
+		deps["jest"] = "^29.7.0"
+		deps["jest-expo"] = "~51.0.0"
+	case models.AppTypeNest:
+		deps["@nestjs/cli"] = "^10.4.0"
+		deps["@nestjs/schematics"] = "^10.1.0"
+		deps["@types/node"] = "^22.0.0"
+		deps["@types/jest"] = "^29.5.0"
+		deps["jest"] = "^29.7.0"
+		deps["ts-jest"] = "^29.2.0"
+	case models.AppTypeBasicNode:
+		deps["vitest"] = "^2.1.0"
+		if appOption(app, "typescript") {
+			deps["@types/node"] = "^22.0.0"
+			deps["tsx"] = "^4.19.0"
+		} else {
+			delete(deps, "typescript")
+		}
+	}
+
+	for option, optionDeps := range appOptionDependencies[app.Type] {
+		if appOption(app, option) {
+			for name, version := range optionDeps.devDependencies {
+				deps[name] = version
+			}
+		}
+	}
+	return deps
+}
+
+// optionDependencies lists the packages pulled in by a single application option.
+type optionDependencies struct {
+	dependencies    map[string]string
+	devDependencies map[string]string
+}
+
+// tailwindDependencies are shared by every web application type.
+var tailwindDependencies = optionDependencies{
+	devDependencies: map[string]string{"tailwindcss": "^3.4.0", "postcss": "^8.4.0", "autoprefixer": "^10.4.0"},
+}
+
+// appOptionDependencies maps each application option to the packages it requires.
+var appOptionDependencies = map[models.AppType]map[string]optionDependencies{
+	models.AppTypeReact: {
+		"tailwind": tailwindDependencies,
+		"router":   {dependencies: map[string]string{"react-router-dom": "^6.26.0"}},
+		"stripe":   {dependencies: map[string]string{"@stripe/stripe-js": "^4.5.0"}},
+		"shadcn":   {dependencies: map[string]string{"clsx": "^2.1.0", "tailwind-merge": "^2.5.0"}},
+	},
+	models.AppTypeNext: {
+		"tailwind": tailwindDependencies,
+		"auth-js":  {dependencies: map[string]string{"next-auth": "^5.0.0-beta.22"}},
+		"stripe":   {dependencies: map[string]string{"stripe": "^16.12.0"}},
+	},
+	models.AppTypeTanStack: {
+		"tailwind": tailwindDependencies,
+		"query":    {dependencies: map[string]string{"@tanstack/react-query": "^5.56.0"}},
+	},
+	models.AppTypeExpo: {
+		"expo-router":   {dependencies: map[string]string{"expo-router": "~3.5.0"}},
+		"tamagui":       {dependencies: map[string]string{"tamagui": "^1.112.0", "@tamagui/config": "^1.112.0"}, devDependencies: map[string]string{"@tamagui/babel-plugin": "^1.112.0"}},
+		"dev-tools":     {dependencies: map[string]string{"expo-dev-client": "~4.0.0"}},
+		"notifications": {dependencies: map[string]string{"expo-notifications": "~0.28.0"}},
+	},
+	models.AppTypeNest: {
+		"prisma":     {dependencies: map[string]string{"@prisma/client": "^5.20.0"}, devDependencies: map[string]string{"prisma": "^5.20.0"}},
+		"graphql":    {dependencies: map[string]string{"@nestjs/graphql": "^12.2.0", "@nestjs/apollo": "^12.2.0", "@apollo/server": "^4.11.0", "graphql": "^16.9.0"}},
+		"auth":       {dependencies: map[string]string{"@nestjs/jwt": "^10.2.0", "@nestjs/passport": "^10.0.0", "passport": "^0.7.0", "passport-jwt": "^4.0.1"}, devDependencies: map[string]string{"@types/passport-jwt": "^4.0.1"}},
+		"swagger":    {dependencies: map[string]string{"@nestjs/swagger": "^7.4.0"}},
+		"validation": {dependencies: map[string]string{"class-validator": "^0.14.0", "class-transformer": "^0.5.1"}},
+	},
+	models.AppTypeBasicNode: {
+		"express": {dependencies: map[string]string{"express": "^4.21.0"}, devDependencies: map[string]string{"@types/express": "^4.17.21"}},
+		"fastify": {dependencies: map[string]string{"fastify": "^5.0.0"}},
+	},
+}
+
+// renderReadme returns the README of the generated project.
+func renderReadme(project models.ProjectConfig) string {
+	var b strings.Builder
+
+	b.WriteString("# " + project.Name + "\n\n")
+	if project.Description != "" {
+		b.WriteString(project.Description + "\n\n")
+	}
+
+	if IsSingleApp(project) && len(project.Applications) > 0 {
+		fmt.Fprintf(&b, "A %s application.\n\n", models.AppTypeNames[project.Applications[0].Type])
+	} else if len(project.Applications) > 0 {
+		b.WriteString("## Applications\n\n")
+		for _, app := range project.Applications {
+			fmt.Fprintf(&b, "- `%s` - %s\n", AppDir(project, app), models.AppTypeNames[app.Type])
+		}
+		b.WriteString("\n")
+	}
+
+	pm := ProjectPackageManager(project)
+	b.WriteString("## Getting started\n\n")
+	b.WriteString("```bash\n")
+	b.WriteString(InstallCommand(pm) + "\n")
+	b.WriteString(RunCommand(pm, "dev") + "\n")
+	b.WriteString("```\n\n")
+	fmt.Fprintf(&b, "Commit the `%s` the first install writes: CI installs with `%s`, which fails when the lockfile is missing or out of date.\n\n",
+		packageManagers[pm].lockfile, packageManagers[pm].install)
+	b.WriteString("Generated with [Teapot](https://github.com/robbeverhelst/teapot) from `teapot.yml`.\n")
+
+	return b.String()
+}
+
+// renderDockerfile returns the Dockerfile for the project layout. Workspaces build
+// the app selected by the APP build argument; single-app projects build the root.
+func renderDockerfile(project models.ProjectConfig) string {
+	pm := projectPackageManager(project)
+	start := execForm(RunCommand(ProjectPackageManager(project), "start"))
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "FROM %s AS base\n", pm.image)
+	if pm.corepack {
+		b.WriteString("RUN corepack enable\n")
+	}
+	if !IsSingleApp(project) {
+		b.WriteString("WORKDIR /repo\n\nARG APP\nCOPY . .\n")
+		fmt.Fprintf(&b, "RUN %s\n", pm.install)
+		fmt.Fprintf(&b, "RUN %s\n", appBuildCommand(project))
+		fmt.Fprintf(&b, "\nWORKDIR /repo/apps/${APP}\nCMD %s\n", start)
+		return b.String()
+	}
+
+	b.WriteString("WORKDIR /app\n\nCOPY . .\n")
+	fmt.Fprintf(&b, "RUN %s\n", pm.install)
+	if appsHaveScript(project, "build") {
+		fmt.Fprintf(&b, "RUN %s\n", RunCommand(ProjectPackageManager(project), "build"))
+	}
+	fmt.Fprintf(&b, "\nCMD %s\n", start)
+	return b.String()
+}
+
+// renderDockerCompose returns a compose file with one service per deployable app.
+func renderDockerCompose(project models.ProjectConfig) string {
+	var b strings.Builder
+	b.WriteString("services:\n")
+
+	port := 3000
+	for _, app := range project.Applications {
+		// Mobile apps are not containerised
+		if app.Type == models.AppTypeExpo {
+			continue
+		}
+		name := AppFolderName(app)
+		fmt.Fprintf(&b, "  %s:\n", name)
+		b.WriteString("    build:\n")
+		b.WriteString("      context: .\n")
+		if !IsSingleApp(project) {
+			b.WriteString("      args:\n")
+			fmt.Fprintf(&b, "        APP: %s\n", name)
+		}
+		b.WriteString("    environment:\n")
+		fmt.Fprintf(&b, "      PORT: \"%d\"\n", port)
+		b.WriteString("    ports:\n")
+		fmt.Fprintf(&b, "      - \"%d:%d\"\n", port, port)
+		port++
+	}
+
+	if port == 3000 {
+		b.WriteString("  {}\n")
+	}
+	return b.String()
+}
+
+// ciScripts returns the root scripts the CI pipeline runs after installing, in order.
+func ciScripts(project models.ProjectConfig) []string {
+	var scripts []string
+	if hasFeature(project, "linting") {
+		scripts = append(scripts, "lint")
+	}
+	if hasFeature(project, "testing") {
+		scripts = append(scripts, "test")
+	}
+	if appsHaveScript(project, "build") {
+		scripts = append(scripts, "build")
+	}
+	return scripts
+}
+
+// renderGitHubWorkflow returns the GitHub Actions CI workflow.
+func renderGitHubWorkflow(project models.ProjectConfig) string {
+	var b strings.Builder
+	b.WriteString(`name: CI
+
+on:
+  push:
+    branches: [main]
+  pull_request:
+
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+`)
+	pm := projectPackageManager(project)
+	if ProjectPackageManager(project) == models.PackageManagerBun {
+		b.WriteString("      - uses: oven-sh/setup-bun@v2\n")
+	} else {
+		b.WriteString("      - uses: actions/setup-node@v4\n        with:\n          node-version: 20\n")
+	}
+	if pm.corepack {
+		b.WriteString("      - run: corepack enable\n")
+	}
+	fmt.Fprintf(&b, "      - run: %s\n", pm.install)
+	for _, script := range ciScripts(project) {
+		fmt.Fprintf(&b, "      - run: %s\n", RunCommand(ProjectPackageManager(project), script))
+	}
+
+	if hasFeature(project, "security") {
+		b.WriteString(`
+  security:
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: github/codeql-action/init@v3
+        with:
+          languages: javascript-typescript
+      - uses: github/codeql-action/analyze@v3
+`)
+	}
+
+	if hasFeature(project, "docker") {
+		b.WriteString(`
+  docker:
+    needs: build
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - uses: docker/setup-buildx-action@v3
+      - uses: docker/build-push-action@v6
+        with:
+          context: .
+          push: false
+`)
+	}
+
+	if hasFeature(project, "deployment") {
+		b.WriteString(`
+  deploy:
+    needs: build
+    if: github.ref == 'refs/heads/main'
+    runs-on: ubuntu-latest
+    steps:
+      - uses: actions/checkout@v4
+      - run: echo "Add your deployment steps here"
+`)
+	}
+	return b.String()
+}
+
+// renderGitLabCI returns the GitLab CI pipeline.
+func renderGitLabCI(project models.ProjectConfig) string {
+	var b strings.Builder
+	pm := projectPackageManager(project)
+	fmt.Fprintf(&b, "image: %s\n", pm.image)
+	b.WriteString(`
+stages:
+  - verify
+  - build
+
+cache:
+  paths:
+    - node_modules/
+
+before_script:
+`)
+	if pm.corepack {
+		b.WriteString("  - corepack enable\n")
+	}
+	fmt.Fprintf(&b, "  - %s\n", pm.install)
+	for _, script := range ciScripts(project) {
+		stage := "verify"
+		if script == "build" {
+			stage = "build"
+		}
+		fmt.Fprintf(&b, "\n%s:\n  stage: %s\n  script:\n    - %s\n", script, stage, RunCommand(ProjectPackageManager(project), script))
+	}
+	return b.String()
+}
+
+// renderJenkinsfile returns the declarative Jenkins pipeline.
+func renderJenkinsfile(project models.ProjectConfig) string {
+	var b strings.Builder
+	b.WriteString("pipeline {\n  agent any\n  stages {\n")
+	pm := projectPackageManager(project)
+	b.WriteString("    stage('Install') {\n      steps {\n")
+	if pm.corepack {
+		b.WriteString("        sh 'corepack enable'\n")
+	}
+	fmt.Fprintf(&b, "        sh '%s'\n      }\n    }\n", pm.install)
+	for _, script := range ciScripts(project) {
+		fmt.Fprintf(&b, "    stage('%s') {\n      steps {\n        sh '%s'\n      }\n    }\n",
+			strings.ToUpper(script[:1])+script[1:], RunCommand(ProjectPackageManager(project), script))
+	}
+	b.WriteString("  }\n}\n")
+	return b.String()
+}
+
+const gitignoreContent = `node_modules/
+dist/
+build/
+.next/
+.expo/
+.turbo/
+.nx/
+coverage/
+.env
+.env.local
+*.log
+.DS_Store
+`
+
+const editorconfigContent = `root = true
+
+[*]
+charset = utf-8
+end_of_line = lf
+indent_style = space
+indent_size = 2
+insert_final_newline = true
+trim_trailing_whitespace = true
+`
+
+const tsconfigBaseContent = `{
+  "compilerOptions": {
+    "target": "ES2022",
+    "module": "ESNext",
+    "moduleResolution": "Bundler",
+    "strict": true,
+    "esModuleInterop": true,
+    "skipLibCheck": true,
+    "forceConsistentCasingInFileNames": true,
+    "resolveJsonModule": true,
+    "isolatedModules": true
+  }
+}
+`
+
+const prettierContent = `{
+  "semi": true,
+  "singleQuote": false,
+  "trailingComma": "all",
+  "printWidth": 100
+}
+`
+
+const eslintContent = `import tseslint from "typescript-eslint";
+
+export default tseslint.config(...tseslint.configs.recommended, {
+  ignores: ["**/dist/**", "**/.next/**", "**/node_modules/**"],
+});
+`
+
+const sharedESLintContent = `import config from "%s";
+
+export default config;
+`
+
+const biomeContent = `{
+  "$schema": "https://biomejs.dev/schemas/1.9.0/schema.json",
+  "organizeImports": { "enabled": true },
+  "formatter": { "enabled": true, "indentStyle": "space" },
+  "linter": {
+    "enabled": true,
+    "rules": { "recommended": true }
+  }
+}
+`
+
+const dockerignoreContent = `node_modules
+**/node_modules
+**/dist
+**/.next
+.git
+`
+
+const pulumiProjectContent = `name: %s-infra
+runtime: nodejs
+description: Kubernetes infrastructure managed with Pulumi
+`
+
+const pulumiIndexContent = `import * as k8s from "@pulumi/kubernetes";
+
+const namespace = new k8s.core.v1.Namespace("app");
+
+export const namespaceName = namespace.metadata.name;
+`
+
+const terraformContent = `terraform {
+  required_version = ">= 1.5.0"
+}
+
+# Add your cloud provider and resources here.
+`
@@ -0,0 +1,401 @@
+package generator
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"teapot/internal/errors"
+	"teapot/internal/models"
+	"teapot/internal/validation"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Diagnostic is a problem found in teapot.yml, with the position it was found at.
+type Diagnostic struct {
+	// Line is the 1-based line of the offending key or value
+	Line int
+	// Column is the 1-based column of the offending key or value
+	Column int
+	// Err describes the problem; deprecations are warnings, everything else is an error
+	Err *errors.TeapotError
+	// Suggestion tells how to fix the problem, empty if there is no obvious fix
+	Suggestion string
+}
+
+// IsWarning reports whether the diagnostic does not make the file invalid.
+func (d Diagnostic) IsWarning() bool {
+	return d.Err.Type == errors.ErrorTypeDeprecation
+}
+
+// Severity returns "warning" or "error".
+func (d Diagnostic) Severity() string {
+	if d.IsWarning() {
+		return "warning"
+	}
+	return "error"
+}
+
+// yamlErrorLine extracts the line number from yaml.v3 syntax errors
+var yamlErrorLine = regexp.MustCompile(`^yaml: line (\d+): `)
+
+// diagnostics collects the problems found while checking a document
+type diagnostics struct {
+	choices SchemaChoices
+	list    []Diagnostic
+}
+
+// add records a problem at the position of node
+func (d *diagnostics) add(node *yaml.Node, err *errors.TeapotError, suggestion string) {
+	d.list = append(d.list, Diagnostic{Line: node.Line, Column: node.Column, Err: err, Suggestion: suggestion})
+}
+
+// hasErrors reports whether any problem other than a warning was found
+func (d *diagnostics) hasErrors() bool {
+	for _, diagnostic := range d.list {
+		if !diagnostic.IsWarning() {
+			return true
+		}
+	}
+	return false
+}
+
+// invalid records a validation error at the position of node
+func (d *diagnostics) invalid(node *yaml.Node, message, suggestion string) {
+	d.add(node, errors.NewValidationError(message, nil), suggestion)
+}
+
+// ValidateTeapotYAML checks teapot.yml content and reports every problem with
+// its line and column. Values are checked against choices, the same choices
+// the JSON Schema is built from. Diagnostics are sorted by position.
+func ValidateTeapotYAML(data []byte, choices SchemaChoices) []Diagnostic {
+	d := &diagnostics{choices: choices}
+
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		line, message := 1, strings.TrimPrefix(err.Error(), "yaml: ")
+		if match := yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+			line, _ = strconv.Atoi(match[1])
+			message = strings.TrimPrefix(err.Error(), match[0])
+		}
+		d.add(&yaml.Node{Line: line, Column: 1}, errors.NewValidationError(message, err), "fix the YAML syntax")
+		return d.list
+	}
+	if len(doc.Content) == 0 {
+		d.invalid(&yaml.Node{Line: 1, Column: 1}, "teapot.yml is empty", "run 'teapot init' and save the configuration from the preview screen")
+		return d.list
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		d.invalid(root, "teapot.yml must be a mapping of settings", "start from a file saved by 'teapot init'")
+		return d.list
+	}
+
+	// Older versions are checked in their migrated shape; the moved nodes keep
+	// their original positions
+	version := mappingValue(root, "version")
+	from, deprecations, err := migrateDocument(root)
+	position := root
+	if version != nil {
+		position = version
+	}
+	if conflict, ok := err.(*migrationConflict); ok {
+		d.add(conflict.node, conflict.err, conflict.suggestion)
+		return d.list
+	}
+	if err != nil {
+		d.invalid(position, err.Error(), fmt.Sprintf("set version to %q", teapotYAMLVersion))
+		return d.list
+	}
+	for _, deprecated := range deprecations {
+		d.add(deprecated.node, deprecated.err, "run 'teapot migrate-config'")
+	}
+	if from != teapotYAMLVersion {
+		d.add(position, errors.NewDeprecationError(fmt.Sprintf("teapot.yml version %s is deprecated", from), nil), "run 'teapot migrate-config'")
+	}
+
+	// Unknown settings do not stop decoding, so values are still checked
+	// unless a value has the wrong shape
+	structured := d.checkStructure(root, reflect.TypeOf(TeapotConfig{}), "")
+	var config TeapotConfig
+	if err := root.Decode(&config); err != nil {
+		if structured {
+			d.invalid(root, err.Error(), "")
+		}
+	} else {
+		project := config.toProjectConfig()
+		d.checkProject(root, project)
+		// Anything the checks above do not cover still fails generation
+		if !d.hasErrors() {
+			if _, err := BuildPlan(project); err != nil {
+				d.add(root, errors.AsTeapotError(err, errors.ErrorTypeValidation), "")
+			}
+		}
+	}
+
+	sort.SliceStable(d.list, func(i, j int) bool {
+		if d.list[i].Line != d.list[j].Line {
+			return d.list[i].Line < d.list[j].Line
+		}
+		return d.list[i].Column < d.list[j].Column
+	})
+	return d.list
+}
+
+// checkStructure checks that node has the shape of the Go type t: known keys
+// only and values of the right kind. It reports whether the node can be decoded.
+func (d *diagnostics) checkStructure(node *yaml.Node, t reflect.Type, path string) bool {
+	// Empty values decode to the zero value
+	if node.Tag == "!!null" {
+		return true
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		if node.Kind != yaml.MappingNode {
+			d.invalid(node, fmt.Sprintf("%s must be a mapping", displayPath(path)), "")
+			return false
+		}
+		fields := make(map[string]reflect.Type)
+		var names []string
+		for i := 0; i < t.NumField(); i++ {
+			name, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+			fields[name] = t.Field(i).Type
+			names = append(names, name)
+		}
+		ok := true
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			fieldType, known := fields[key.Value]
+			if !known {
+				d.invalid(key, fmt.Sprintf("unknown setting %q in %s", key.Value, displayPath(path)), suggestChoice(key.Value, names))
+				ok = false
+				continue
+			}
+			if !d.checkStructure(value, fieldType, strings.TrimPrefix(path+"."+key.Value, ".")) {
+				ok = false
+			}
+		}
+		return ok
+	case reflect.Slice:
+		if node.Kind != yaml.SequenceNode {
+			d.invalid(node, fmt.Sprintf("%s must be a list", displayPath(path)), "")
+			return false
+		}
+		ok := true
+		for _, item := range node.Content {
+			if !d.checkStructure(item, t.Elem(), path) {
+				ok = false
+			}
+		}
+		return ok
+	case reflect.Map:
+		if node.Kind != yaml.MappingNode {
+			d.invalid(node, fmt.Sprintf("%s must be a mapping", displayPath(path)), "")
+			return false
+		}
+		return true
+	case reflect.Bool:
+		if node.Kind != yaml.ScalarNode || node.Tag != "!!bool" {
+			d.invalid(node, fmt.Sprintf("%s must be true or false, got %q", displayPath(path), node.Value), "")
+			return false
+		}
+		return true
+	default:
+		if node.Kind != yaml.ScalarNode {
+			d.invalid(node, fmt.Sprintf("%s must be a single value", displayPath(path)), "")
+			return false
+		}
+		return true
+	}
+}
+
+// checkProject checks the values of a structurally valid document.
+func (d *diagnostics) checkProject(root *yaml.Node, project models.ProjectConfig) {
+	projectNode := mappingValue(root, "project")
+	if projectNode == nil {
+		d.invalid(root, "missing project settings", "add a project section with a name and architecture")
+		return
+	}
+
+	nameNode := valueOrKey(projectNode, "name")
+	if err := validation.ValidateProjectName(project.Name); err != nil {
+		d.add(nameNode, errors.AsTeapotError(err, errors.ErrorTypeValidation), fmt.Sprintf("use %q", validation.SanitizeProjectName(project.Name)))
+	}
+	if err := validation.ValidateProjectDescription(project.Description); err != nil {
+		d.add(valueOrKey(projectNode, "description"), errors.AsTeapotError(err, errors.ErrorTypeValidation), "shorten it to 200 characters of plain text")
+	}
+	d.checkChoice(valueOrKey(projectNode, "architecture"), "project.architecture", string(project.Architecture), d.choices.Architectures, false)
+	d.checkChoice(valueOrKey(projectNode, "packageManager"), "project.packageManager", string(project.PackageManager), d.choices.PackageManagers, true)
+
+	appsNode := mappingValue(root, "applications")
+	if appsNode != nil {
+		if IsSingleApp(project) && len(project.Applications) > 1 {
+			d.invalid(appsNode, fmt.Sprintf("single application projects support one application, got %d", len(project.Applications)),
+				"remove applications or use a monorepo architecture")
+		}
+		folders, ids := make(map[string]bool), make(map[string]bool)
+		for i, app := range project.Applications {
+			d.checkApplication(appsNode.Content[i], project, app, folders, ids)
+		}
+	}
+
+	if packagesNode := mappingValue(root, "packages"); packagesNode != nil {
+		seen := make(map[string]bool)
+		for _, node := range packagesNode.Content {
+			if seen[node.Value] {
+				d.invalid(node, fmt.Sprintf("duplicate shared package %q", node.Value), "remove the duplicate")
+			}
+			seen[node.Value] = true
+			d.checkChoice(node, "packages", node.Value, d.choices.Packages, false)
+		}
+	}
+
+	if devTools := mappingValue(root, "devTools"); devTools != nil {
+		d.checkChoice(mappingValue(devTools, "linting"), "devTools.linting", project.DevTools.Linting, d.choices.LintingTools, true)
+	}
+	if ci := mappingValue(root, "ciPipeline"); ci != nil {
+		d.checkChoice(mappingValue(ci, "provider"), "ciPipeline.provider", project.CIPipeline.Provider, d.choices.CIProviders, true)
+		if features := mappingValue(ci, "features"); features != nil {
+			for _, node := range features.Content {
+				d.checkChoice(node, "ciPipeline.features", node.Value, d.choices.CIFeatures, false)
+			}
+		}
+	}
+	if ai := mappingValue(root, "aiTools"); ai != nil && project.AITools.Editor != "" {
+		for _, tool := range strings.Split(project.AITools.Editor, ",") {
+			d.checkChoice(mappingValue(ai, "editor"), "aiTools.editor", tool, d.choices.AITools, false)
+		}
+	}
+}
+
+// checkApplication checks one application: its name, ID, type, folder and options.
+func (d *diagnostics) checkApplication(node *yaml.Node, project models.ProjectConfig, app models.Application, folders, ids map[string]bool) {
+	if app.Name != "" {
+		if err := validation.ValidateAppName(app.Name); err != nil {
+			suggestion := "use letters, digits, - and _"
+			if name := validation.SanitizeAppName(app.Name); name != "" {
+				suggestion = fmt.Sprintf("use %q", name)
+			}
+			d.add(valueOrKey(node, "name"), errors.AsTeapotError(err, errors.ErrorTypeValidation), suggestion)
+		}
+	}
+
+	if app.ID != "" && ids[app.ID] {
+		d.invalid(valueOrKey(node, "id"), fmt.Sprintf("duplicate application ID %q", app.ID),
+			fmt.Sprintf("use %q", NewApplicationID(project.Applications, app.Type)))
+	}
+	ids[app.ID] = true
+
+	typeNode := valueOrKey(node, "type")
+	if !d.checkChoice(typeNode, "applications.type", string(app.Type), d.choices.AppTypes, false) {
+		return
+	}
+
+	dir := AppDir(project, app)
+	if folders[dir] {
+		d.invalid(valueOrKey(node, "name"), fmt.Sprintf("duplicate application folder %q", dir), "give every application a different name")
+	}
+	folders[dir] = true
+
+	optionsNode := mappingValue(node, "options")
+	if optionsNode == nil {
+		return
+	}
+	var keys []string
+	for _, option := range d.choices.AppOptions[string(app.Type)] {
+		keys = append(keys, option.Key)
+	}
+	for i := 0; i+1 < len(optionsNode.Content); i += 2 {
+		key, value := optionsNode.Content[i], optionsNode.Content[i+1]
+		if !containsString(keys, key.Value) {
+			d.invalid(key, fmt.Sprintf("unknown %s option %q", models.AppTypeNames[app.Type], key.Value), suggestChoice(key.Value, keys))
+			continue
+		}
+		if value.Kind != yaml.ScalarNode || value.Tag != "!!bool" {
+			d.invalid(value, fmt.Sprintf("option %q must be true or false, got %q", key.Value, value.Value), "")
+		}
+	}
+}
+
+// checkChoice reports a value that is not one of choices. Empty values are
+// accepted for optional settings. It reports whether the value is valid.
+func (d *diagnostics) checkChoice(node *yaml.Node, path, value string, choices []string, optional bool) bool {
+	if (optional && value == "") || len(choices) == 0 || containsString(choices, value) {
+		return true
+	}
+	if node == nil {
+		return false
+	}
+	d.invalid(node, fmt.Sprintf("%s: unknown value %q", displayPath(path), value), suggestChoice(value, choices))
+	return false
+}
+
+// valueOrKey returns the value of key in a mapping, or the mapping itself when
+// the key is missing, so a diagnostic always has a position.
+func valueOrKey(mapping *yaml.Node, key string) *yaml.Node {
+	if value := mappingValue(mapping, key); value != nil {
+		return value
+	}
+	return mapping
+}
+
+// displayPath names a settings path in messages
+func displayPath(path string) string {
+	if path == "" {
+		return "teapot.yml"
+	}
+	return path
+}
+
+// suggestChoice suggests the choice closest to value, or lists all choices.
+func suggestChoice(value string, choices []string) string {
+	best, bestDistance := "", len(value)/2+1
+	for _, choice := range choices {
+		if distance := editDistance(strings.ToLower(value), strings.ToLower(choice)); distance < bestDistance {
+			best, bestDistance = choice, distance
+		}
+	}
+	if best != "" {
+		return fmt.Sprintf("did you mean %q?", best)
+	}
+	if len(choices) == 0 {
+		return ""
+	}
+	return "use one of: " + strings.Join(choices, ", ")
+}
+
+// editDistance returns the Levenshtein distance between a and b.
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// containsString reports whether values includes value
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,114 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+// validTeapotYAML is a current teapot.yml the diagnostics tests break in one place each
+const validTeapotYAML = `version: "2.0"
+project:
+    name: demo
+    description: ""
+    architecture: turborepo
+applications:
+    - id: app-next
+      name: web
+      type: next
+      options:
+        tailwind: true
+devTools:
+    linting: biome
+    typescript: true
+ciPipeline:
+    provider: github
+    features: []
+aiTools:
+    editor: cursor
+`
+
+func TestValidateTeapotYAML(t *testing.T) {
+	tests := []struct {
+		name       string
+		old, new   string
+		position   string
+		message    string
+		suggestion string
+	}{
+		{"project name", "name: demo", "name: my/app", "3:11", "path separators", `use "myapp"`},
+		{"application name", "name: web", "name: ../../escape", "8:13", "path separators", `use "escape"`},
+		{"application name space", "name: web", "name: my app", "8:13", "invalid character ' '", `use "my-app"`},
+		{"application id", "devTools:", "    - id: app-next\n      name: admin\n      type: next\ndevTools:", "12:11", `duplicate application ID "app-next"`, `use "app-next-2"`},
+		{"unknown setting", "typescript: true", "typscript: true", "14:5", `unknown setting "typscript"`, `did you mean "typescript"?`},
+		{"unknown option", "tailwind: true", "tailwnd: true", "11:9", `unknown Next.js option "tailwnd"`, `did you mean "tailwind"?`},
+		{"option value", "tailwind: true", "tailwind: sure", "11:19", `must be true or false`, ""},
+		{"application type", "type: next", "type: svelte", "9:13", `unknown value "svelte"`, "use one of: next, nest"},
+		{"package manager", "architecture: turborepo", "architecture: turborepo\n    packageManager: pnp", "6:21", `unknown value "pnp"`, `did you mean "pnpm"?`},
+		{"linting", "linting: biome", "linting: biom", "13:14", `unknown value "biom"`, `did you mean "biome"?`},
+		{"ai tool", "editor: cursor", "editor: cursor,vim", "19:13", `unknown value "vim"`, "use one of: cursor, none"},
+		{"wrong shape", "features: []", "features: testing", "17:15", "must be a list", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := strings.Replace(validTeapotYAML, tt.old, tt.new, 1)
+			diagnostics := ValidateTeapotYAML([]byte(content), testSchemaChoices())
+			if len(diagnostics) != 1 {
+				t.Fatalf("Expected 1 diagnostic, got %+v", diagnostics)
+			}
+			d := diagnostics[0]
+			if position := fmt.Sprintf("%d:%d", d.Line, d.Column); position != tt.position {
+				t.Errorf("Expected position %s, got %s", tt.position, position)
+			}
+			if !strings.Contains(d.Err.Message, tt.message) || d.IsWarning() {
+				t.Errorf("Expected an error containing %q, got %s: %q", tt.message, d.Severity(), d.Err.Message)
+			}
+			if d.Suggestion != tt.suggestion {
+				t.Errorf("Expected suggestion %q, got %q", tt.suggestion, d.Suggestion)
+			}
+		})
+	}
+}
+
+func TestValidateTeapotYAML_Syntax(t *testing.T) {
+	content := strings.Replace(validTeapotYAML, "    typescript: true", "\ttypescript: true", 1)
+	diagnostics := ValidateTeapotYAML([]byte(content), testSchemaChoices())
+	if len(diagnostics) != 1 {
+		t.Fatalf("Expected 1 diagnostic, got %+v", diagnostics)
+	}
+	// The line is the one the YAML scanner reports, which can be the line before the problem
+	d := diagnostics[0]
+	if d.Line < 13 || d.Line > 14 || strings.HasPrefix(d.Err.Message, "line") || !strings.Contains(d.Err.Message, "tab character") {
+		t.Errorf("Expected the syntax error near line 14 without a line prefix, got %d: %q", d.Line, d.Err.Message)
+	}
+}
+
+func TestValidateTeapotYAML_Valid(t *testing.T) {
+	if diagnostics := ValidateTeapotYAML([]byte(validTeapotYAML), testSchemaChoices()); len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %+v", diagnostics)
+	}
+
+	// Every problem is reported, in file order
+	content := strings.NewReplacer("name: demo", "name: -demo", "linting: biome", "linting: eslint").Replace(validTeapotYAML)
+	diagnostics := ValidateTeapotYAML([]byte(content), testSchemaChoices())
+	if len(diagnostics) != 2 || diagnostics[0].Line != 3 || diagnostics[1].Line != 13 {
+		t.Errorf("Expected diagnostics on lines 3 and 13, got %+v", diagnostics)
+	}
+}
+
+func TestValidateTeapotYAML_OlderVersion(t *testing.T) {
+	diagnostics := ValidateTeapotYAML([]byte(teapotYAMLV1), testSchemaChoices())
+	if len(diagnostics) != 2 {
+		t.Fatalf("Expected 2 deprecation warnings, got %+v", diagnostics)
+	}
+	for _, d := range diagnostics {
+		if !d.IsWarning() || d.Suggestion != "run 'teapot migrate-config'" {
+			t.Errorf("Expected a migration warning, got %+v", d)
+		}
+	}
+	// The moved setting is reported where it is written, not where it moves to
+	if diagnostics[1].Line != 6 || diagnostics[1].Column != 1 {
+		t.Errorf("Expected the architecture warning at 6:1, got %d:%d", diagnostics[1].Line, diagnostics[1].Column)
+	}
+}
@@ -0,0 +1,484 @@
+package generator
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"teapot/internal/errors"
+	"teapot/internal/models"
+	"teapot/internal/validation"
+)
+
+// File is a single file the generator will emit, relative to the project root.
+type File struct {
+	// Path is the slash-separated path relative to the project root
+	Path string
+	// Content is the full file content
+	Content []byte
+	// Mode is the file permission used when writing
+	Mode os.FileMode
+	// Template is the ID of the template the file was rendered from, if any
+	Template string
+	// TemplateVersion identifies the revision of the template, if any
+	TemplateVersion string
+}
+
+// Step groups the files emitted for one stage of project generation.
+type Step struct {
+	// Name is the label shown once the step has finished
+	Name string
+	// Description is the label shown while the step is running
+	Description string
+	// Files lists the files written by this step
+	Files []File
+}
+
+// Plan is the ordered list of steps required to generate a project.
+type Plan struct {
+	// Project is the configuration the plan was built from
+	Project models.ProjectConfig
+	// Steps contains the generation steps in execution order
+	Steps []Step
+}
+
+// FileCount returns the total number of files across all steps.
+func (p *Plan) FileCount() int {
+	count := 0
+	for _, step := range p.Steps {
+		count += len(step.Files)
+	}
+	return count
+}
+
+// FormatSize renders a byte count for display, e.g. "512 B" or "1.4 KB".
+func FormatSize(size int) string {
+	switch {
+	case size < 1024:
+		return fmt.Sprintf("%d B", size)
+	case size < 1024*1024:
+		return fmt.Sprintf("%.1f KB", float64(size)/1024)
+	default:
+		return fmt.Sprintf("%.1f MB", float64(size)/(1024*1024))
+	}
+}
+
+// FormatPlanForDisplay lists every file in the plan grouped by step, with its size
+// and the template it was rendered from. Files built in code show "(generated)".
+func FormatPlanForDisplay(plan *Plan) string {
+	width := 0
+	total := 0
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			if len(file.Path) > width {
+				width = len(file.Path)
+			}
+			total += len(file.Content)
+		}
+	}
+
+	var b strings.Builder
+	for i, step := range plan.Steps {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		b.WriteString(step.Name + "\n")
+		for _, file := range step.Files {
+			source := file.Template
+			if source == "" {
+				source = "(generated)"
+			}
+			fmt.Fprintf(&b, "  %-*s  %8s  %s\n", width, file.Path, FormatSize(len(file.Content)), source)
+		}
+	}
+	fmt.Fprintf(&b, "\n%d files, %s\n", plan.FileCount(), FormatSize(total))
+
+	return b.String()
+}
+
+// EventType identifies the kind of progress event emitted during generation.
+type EventType int
+
+const (
+	// EventStepStarted is emitted before the first file of a step is written
+	EventStepStarted EventType = iota
+	// EventFileWritten is emitted after each file has been written
+	EventFileWritten
+	// EventStepFinished is emitted once every file of a step has been written
+	EventStepFinished
+	// EventFileSkipped is emitted for each file kept as is when merging into an existing directory
+	EventFileSkipped
+	// EventBackupCreated is emitted after an existing directory was moved aside before overwriting
+	EventBackupCreated
+)
+
+// Event reports generation progress to the caller.
+type Event struct {
+	// Type identifies the kind of event
+	Type EventType
+	// Step is the index of the step within the plan
+	Step int
+	// StepName is the name of the step the event belongs to
+	StepName string
+	// Path is the written or skipped file path, or the backup directory for EventBackupCreated
+	Path string
+}
+
+// Engine turns a project configuration into files on disk.
+type Engine struct {
+	project   models.ProjectConfig
+	targetDir string
+	policy    ConflictPolicy
+}
+
+// NewEngine creates a generator engine that writes the project into targetDir.
+func NewEngine(project models.ProjectConfig, targetDir string) *Engine {
+	return &Engine{
+		project:   project,
+		targetDir: targetDir,
+		policy:    ConflictAbort,
+	}
+}
+
+// SetConflictPolicy sets how generation treats a target directory that already has content.
+func (e *Engine) SetConflictPolicy(policy ConflictPolicy) {
+	e.policy = policy
+}
+
+// TargetDir returns the directory the project is generated into.
+func (e *Engine) TargetDir() string {
+	return e.targetDir
+}
+
+// Plan builds the file plan for the engine's project without touching disk.
+func (e *Engine) Plan() (*Plan, error) {
+	return BuildPlan(e.project)
+}
+
+// Run builds the plan and writes it to disk, reporting progress through emit.
+func (e *Engine) Run(emit func(Event)) error {
+	plan, err := e.Plan()
+	if err != nil {
+		return err
+	}
+	return e.Generate(context.Background(), plan, emit)
+}
+
+// Generate writes every file in the plan below the target directory.
+// Files are written to a staging directory next to the target and only moved
+// into place once every step has succeeded, so a failed run leaves the target
+// as it was. A target that already has content is handled according to the
+// conflict policy. Failures are reported as system errors.
+// Cancelling ctx stops the run before anything is moved into the target and
+// removes the staging directory.
+// emit may be nil when the caller is not interested in progress.
+func (e *Engine) Generate(ctx context.Context, plan *Plan, emit func(Event)) error {
+	if emit == nil {
+		emit = func(Event) {}
+	}
+
+	target, err := filepath.Abs(e.targetDir)
+	if err != nil {
+		return errors.NewSystemError(fmt.Sprintf("invalid target directory %s: %v", e.targetDir, err), err)
+	}
+	state, err := InspectTarget(target)
+	if err != nil {
+		return errors.NewSystemError(fmt.Sprintf("failed to inspect %s: %v", e.targetDir, err), err)
+	}
+	conflict := state.HasContent()
+	if conflict && e.policy != ConflictMerge && e.policy != ConflictOverwrite {
+		return errors.NewValidationError(fmt.Sprintf("%s already exists and is %s", e.targetDir, state), nil)
+	}
+	merge := conflict && e.policy == ConflictMerge
+
+	staging, err := createStagingDir(target)
+	if err != nil {
+		return errors.NewSystemError(fmt.Sprintf("failed to create staging directory for %s: %v", e.targetDir, err), err)
+	}
+	defer os.RemoveAll(staging)
+
+	var staged []string
+	written := make(map[string]bool)
+	for i, step := range plan.Steps {
+		emit(Event{Type: EventStepStarted, Step: i, StepName: step.Name})
+
+		for _, file := range step.Files {
+			if err := ctx.Err(); err != nil {
+				return errors.NewSystemError(fmt.Sprintf("generating %s was cancelled", e.targetDir), err)
+			}
+			if merge && isMetadata(file.Path) {
+				// Teapot's records are always rewritten, keeping the entries of files left as they were
+				if file, err = mergeMetadata(target, file, written); err != nil {
+					return errors.NewSystemError(fmt.Sprintf("%s: %v", step.Description, err), err)
+				}
+			} else if merge && pathExists(target, file.Path) {
+				emit(Event{Type: EventFileSkipped, Step: i, StepName: step.Name, Path: file.Path})
+				continue
+			}
+			if err := writeFile(staging, file); err != nil {
+				return errors.NewSystemError(fmt.Sprintf("%s: %v", step.Description, err), err)
+			}
+			staged = append(staged, file.Path)
+			written[file.Path] = true
+			emit(Event{Type: EventFileWritten, Step: i, StepName: step.Name, Path: file.Path})
+		}
+
+		emit(Event{Type: EventStepFinished, Step: i, StepName: step.Name})
+	}
+
+	if err := ctx.Err(); err != nil {
+		return errors.NewSystemError(fmt.Sprintf("generating %s was cancelled", e.targetDir), err)
+	}
+
+	var backup string
+	if conflict && e.policy == ConflictOverwrite {
+		if backup, err = backupTarget(target); err != nil {
+			return errors.NewSystemError(fmt.Sprintf("failed to back up %s: %v", e.targetDir, err), err)
+		}
+		emit(Event{Type: EventBackupCreated, Path: backup})
+	}
+
+	if err := moveIntoPlace(staging, target, staged); err != nil {
+		if backup != "" {
+			// Put the original directory back so the failed run changes nothing
+			os.RemoveAll(target)
+			os.Rename(backup, target)
+		}
+		return errors.NewSystemError(fmt.Sprintf("failed to move generated files into %s: %v", e.targetDir, err), err)
+	}
+	return nil
+}
+
+// BuildPlan computes every file the generator would emit for a project.
+func BuildPlan(project models.ProjectConfig) (*Plan, error) {
+	if err := validation.ValidateProjectName(project.Name); err != nil {
+		return nil, err
+	}
+	if _, ok := models.ArchitectureNames[project.Architecture]; !ok {
+		return nil, fmt.Errorf("unknown architecture %q", project.Architecture)
+	}
+	if _, ok := packageManagers[ProjectPackageManager(project)]; !ok {
+		return nil, fmt.Errorf("unknown package manager %q", project.PackageManager)
+	}
+	if err := validateApplications(project); err != nil {
+		return nil, err
+	}
+	if err := validatePackages(project.Packages); err != nil {
+		return nil, err
+	}
+
+	builders := []func(models.ProjectConfig) (Step, error){
+		baseStep,
+		workspaceStep,
+		packagesStep,
+		appsStep,
+		devToolsStep,
+		infrastructureStep,
+		ciStep,
+	}
+
+	plan := &Plan{Project: project}
+	for _, build := range builders {
+		step, err := build(project)
+		if err != nil {
+			return nil, err
+		}
+		// Steps without files are irrelevant for this configuration
+		if len(step.Files) == 0 {
+			continue
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	// The manifest records every file above, so it is always written last
+	manifest, err := manifestStep(plan.Steps)
+	if err != nil {
+		return nil, err
+	}
+	plan.Steps = append(plan.Steps, manifest)
+
+	return plan, nil
+}
+
+// validateApplications ensures every application has a known type, a valid
+// name and a unique ID, and maps to a unique folder.
+func validateApplications(project models.ProjectConfig) error {
+	if IsSingleApp(project) && len(project.Applications) > 1 {
+		return fmt.Errorf("single application projects support one application, got %d", len(project.Applications))
+	}
+
+	seen := make(map[string]bool)
+	ids := make(map[string]bool)
+	for _, app := range project.Applications {
+		if _, ok := models.AppTypeNames[app.Type]; !ok {
+			return fmt.Errorf("application %q has unknown type %q", app.Name, app.Type)
+		}
+		// An empty name falls back to the default folder for the type
+		if app.Name != "" {
+			if err := validation.ValidateAppName(app.Name); err != nil {
+				return fmt.Errorf("application %q: %w", app.Name, err)
+			}
+		}
+		if app.ID != "" && ids[app.ID] {
+			return fmt.Errorf("duplicate application ID %q", app.ID)
+		}
+		ids[app.ID] = true
+		dir := AppDir(project, app)
+		if seen[dir] {
+			return fmt.Errorf("duplicate application folder %q", dir)
+		}
+		seen[dir] = true
+	}
+	return nil
+}
+
+// NewApplicationID returns the ID of a new application of appType: app-<type>,
+// numbered from 2 when the project already has an application with that ID.
+func NewApplicationID(apps []models.Application, appType models.AppType) string {
+	taken := make(map[string]bool, len(apps))
+	for _, app := range apps {
+		taken[app.ID] = true
+	}
+	id := "app-" + string(appType)
+	for n := 2; taken[id]; n++ {
+		id = fmt.Sprintf("app-%s-%d", appType, n)
+	}
+	return id
+}
+
+// validatePackages ensures every shared package is known and selected once.
+func validatePackages(packages []models.PackageType) error {
+	seen := make(map[models.PackageType]bool)
+	for _, pkg := range packages {
+		if _, ok := models.PackageTypeNames[pkg]; !ok {
+			return fmt.Errorf("unknown shared package %q", pkg)
+		}
+		if seen[pkg] {
+			return fmt.Errorf("duplicate shared package %q", pkg)
+		}
+		seen[pkg] = true
+	}
+	return nil
+}
+
+// createStagingDir creates an empty hidden directory next to target, on the same
+// filesystem so the finished project can be renamed into place. The directory
+// becomes the project root when target is missing, so it gets the permissions
+// of a regular directory instead of the private ones MkdirTemp uses.
+func createStagingDir(target string) (string, error) {
+	parent := filepath.Dir(target)
+	if err := os.MkdirAll(parent, 0755); err != nil {
+		return "", err
+	}
+	staging, err := os.MkdirTemp(parent, "."+filepath.Base(target)+".teapot-staging-")
+	if err != nil {
+		return "", err
+	}
+	if err := os.Chmod(staging, 0755); err != nil {
+		os.RemoveAll(staging)
+		return "", err
+	}
+	return staging, nil
+}
+
+// moveIntoPlace moves the staged files to target. A missing target is created
+// with a single rename; an existing one receives the staged files one by one.
+// Files it replaces are first moved aside into the staging directory, so if a
+// move fails everything added so far is removed and the replaced files are put
+// back.
+func moveIntoPlace(staging, target string, paths []string) error {
+	if _, err := os.Stat(target); os.IsNotExist(err) {
+		return os.Rename(staging, target)
+	} else if err != nil {
+		return err
+	}
+
+	var created, replaced []string
+	var backup string
+	rollback := func() {
+		for i := len(created) - 1; i >= 0; i-- {
+			os.RemoveAll(created[i])
+		}
+		for i := len(replaced) - 1; i >= 0; i-- {
+			os.Rename(filepath.Join(backup, replaced[i]), filepath.Join(target, replaced[i]))
+		}
+	}
+
+	for _, path := range paths {
+		rel := filepath.FromSlash(path)
+		dst := filepath.Join(target, rel)
+		if missing := firstMissing(target, rel); missing != "" {
+			created = append(created, missing)
+		} else if info, err := os.Lstat(dst); err == nil && !info.IsDir() {
+			if backup == "" {
+				backup, err = os.MkdirTemp(staging, "replaced-")
+			}
+			if err == nil {
+				err = os.MkdirAll(filepath.Join(backup, filepath.Dir(rel)), 0755)
+			}
+			if err == nil {
+				err = os.Rename(dst, filepath.Join(backup, rel))
+			}
+			if err != nil {
+				rollback()
+				return fmt.Errorf("failed to back up %s: %w", path, err)
+			}
+			replaced = append(replaced, rel)
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0755); err != nil {
+			rollback()
+			return fmt.Errorf("failed to create directory for %s: %w", path, err)
+		}
+		if err := os.Rename(filepath.Join(staging, rel), dst); err != nil {
+			rollback()
+			return fmt.Errorf("failed to move %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+// firstMissing returns the first path along rel below root that does not exist
+// yet, or an empty string when the whole path already exists.
+func firstMissing(root, rel string) string {
+	path := root
+	for _, part := range strings.Split(rel, string(filepath.Separator)) {
+		path = filepath.Join(path, part)
+		if _, err := os.Lstat(path); os.IsNotExist(err) {
+			return path
+		}
+	}
+	return ""
+}
+
+// writeFile writes a single planned file below root, creating parent directories.
+func writeFile(root string, file File) error {
+	path, err := pathBelow(root, file.Path)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", file.Path, err)
+	}
+
+	mode := file.Mode
+	if mode == 0 {
+		mode = 0644
+	}
+	if err := os.WriteFile(path, file.Content, mode); err != nil {
+		return fmt.Errorf("failed to write %s: %w", file.Path, err)
+	}
+	return nil
+}
+
+// pathBelow joins a slash-separated path to root, refusing paths that do not
+// stay strictly below root.
+func pathBelow(root, path string) (string, error) {
+	joined := filepath.Join(root, filepath.FromSlash(path))
+	rel, err := filepath.Rel(root, joined)
+	if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+		return "", fmt.Errorf("%s is outside of %s", path, root)
+	}
+	return joined, nil
+}
@@ -0,0 +1,567 @@
+package generator
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"teapot/internal/errors"
+	"teapot/internal/models"
+)
+
+// testProject returns a representative project configuration
+func testProject() models.ProjectConfig {
+	return models.ProjectConfig{
+		Name:         "test-project",
+		Description:  "A test project",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{
+			{ID: "app-next", Name: "web", Type: models.AppTypeNext, Options: map[string]interface{}{"tailwind": true}},
+			{ID: "app-nest", Name: "api", Type: models.AppTypeNest, Options: map[string]interface{}{"swagger": true}},
+		},
+		DevTools: models.DevTools{
+			Linting:    "prettier-eslint",
+			TypeScript: true,
+			Husky:      true,
+			LintStaged: true,
+		},
+		Infrastructure: models.Infrastructure{Docker: true, DockerCompose: true},
+		CIPipeline:     models.CIPipeline{Provider: "github", Features: []string{"testing", "linting"}},
+	}
+}
+
+// planPaths collects every planned file path
+func planPaths(plan *Plan) map[string]bool {
+	paths := make(map[string]bool)
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			paths[file.Path] = true
+		}
+	}
+	return paths
+}
+
+func TestBuildPlan_Files(t *testing.T) {
+	plan, err := BuildPlan(testProject())
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	paths := planPaths(plan)
+	expected := []string{
+		"README.md",
+		"teapot.yml",
+		"package.json",
+		"apps/web/package.json",
+		"apps/api/package.json",
+		".husky/pre-commit",
+		"Dockerfile",
+		"docker-compose.yml",
+		".github/workflows/ci.yml",
+	}
+	for _, path := range expected {
+		if !paths[path] {
+			t.Errorf("Expected plan to contain %s", path)
+		}
+	}
+
+	if plan.FileCount() != len(paths) {
+		t.Errorf("Expected FileCount %d to match unique paths %d", plan.FileCount(), len(paths))
+	}
+}
+
+func TestBuildPlan_SkipsEmptySteps(t *testing.T) {
+	project := testProject()
+	project.Infrastructure = models.Infrastructure{}
+	project.CIPipeline = models.CIPipeline{Provider: "skip"}
+
+	plan, err := BuildPlan(project)
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	for _, step := range plan.Steps {
+		if len(step.Files) == 0 {
+			t.Errorf("Expected step %q with no files to be skipped", step.Name)
+		}
+		if step.Name == "Configuring CI/CD" {
+			t.Error("Expected CI step to be skipped when CI is skipped")
+		}
+	}
+}
+
+func TestBuildPlan_InvalidProject(t *testing.T) {
+	project := testProject()
+	project.Name = "../escape"
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected invalid project name to fail")
+	}
+
+	project = testProject()
+	project.Applications[1].Name = "web"
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected duplicate application folders to fail")
+	}
+
+	project = testProject()
+	project.Architecture = "lerna"
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected unknown architecture to fail")
+	}
+
+	project = testProject()
+	project.Applications[0].Type = "svelte"
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected unknown application type to fail")
+	}
+
+	project = testProject()
+	project.Applications[1].ID = project.Applications[0].ID
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected duplicate application IDs to fail")
+	}
+
+	for _, name := range []string{"../../escape", "a/b", `a\b`, "my app"} {
+		project = testProject()
+		project.Applications[0].Name = name
+		if _, err := BuildPlan(project); err == nil {
+			t.Errorf("Expected application name %q to fail", name)
+		}
+	}
+}
+
+func TestNewApplicationID(t *testing.T) {
+	apps := []models.Application{{ID: "app-next"}, {ID: "app-next-2"}, {ID: "app-nest"}}
+	if id := NewApplicationID(apps, models.AppTypeNext); id != "app-next-3" {
+		t.Errorf("Expected app-next-3, got %s", id)
+	}
+	if id := NewApplicationID(apps, models.AppTypeReact); id != "app-react" {
+		t.Errorf("Expected app-react, got %s", id)
+	}
+}
+
+func TestWriteFile_StaysBelowRoot(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "demo")
+
+	for _, path := range []string{"../escape.txt", "apps/../../escape.txt", ".."} {
+		if err := writeFile(root, File{Path: path, Content: []byte("x")}); err == nil {
+			t.Errorf("Expected %q to be refused", path)
+		}
+	}
+	if _, err := os.Stat(filepath.Join(parent, "escape.txt")); !os.IsNotExist(err) {
+		t.Errorf("Expected nothing to be written outside the root, got %v", err)
+	}
+
+	if err := writeFile(root, File{Path: "apps/web/../api/index.ts", Content: []byte("x")}); err != nil {
+		t.Errorf("Expected a path that stays below the root to be written, got error: %v", err)
+	}
+}
+
+func TestEngine_Run(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "test-project")
+	engine := NewEngine(testProject(), target)
+
+	var started, finished, written int
+	err := engine.Run(func(event Event) {
+		switch event.Type {
+		case EventStepStarted:
+			started++
+		case EventStepFinished:
+			finished++
+		case EventFileWritten:
+			written++
+		}
+	})
+	if err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+
+	plan, _ := engine.Plan()
+	if started != len(plan.Steps) || finished != len(plan.Steps) {
+		t.Errorf("Expected %d step events, got %d started and %d finished", len(plan.Steps), started, finished)
+	}
+	if written != plan.FileCount() {
+		t.Errorf("Expected %d file events, got %d", plan.FileCount(), written)
+	}
+
+	if _, err := os.Stat(filepath.Join(target, "apps", "web", "package.json")); err != nil {
+		t.Errorf("Expected app package.json to be written: %v", err)
+	}
+
+	info, err := os.Stat(target)
+	if err != nil {
+		t.Fatalf("Expected the project directory to be created: %v", err)
+	}
+	if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected the project directory to have mode 0755, got %v", info.Mode().Perm())
+	}
+
+	info, err = os.Stat(filepath.Join(target, ".husky", "pre-commit"))
+	if err != nil {
+		t.Fatalf("Expected husky hook to be written: %v", err)
+	}
+	if info.Mode().Perm()&0100 == 0 {
+		t.Error("Expected husky hook to be executable")
+	}
+}
+
+// conflictingPlan returns a plan whose second step cannot be written because
+// its file needs a directory where the first step wrote a file
+func conflictingPlan() *Plan {
+	return &Plan{Steps: []Step{
+		{Name: "Base", Description: "Writing base files", Files: []File{{Path: "README.md", Content: []byte("# demo")}}},
+		{Name: "Apps", Description: "Writing apps", Files: []File{{Path: "README.md/app.json", Content: []byte("{}")}}},
+	}}
+}
+
+// assertNoStaging fails when a staging directory was left behind in dir
+func assertNoStaging(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatalf("Expected to read %s: %v", dir, err)
+	}
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), "teapot-staging") {
+			t.Errorf("Expected staging directory to be cleaned up, found %s", entry.Name())
+		}
+	}
+}
+
+func TestEngine_GenerateFailureLeavesNoTarget(t *testing.T) {
+	parent := t.TempDir()
+	target := filepath.Join(parent, "demo")
+
+	err := NewEngine(testProject(), target).Generate(context.Background(), conflictingPlan(), nil)
+	if err == nil {
+		t.Fatal("Expected generation to fail")
+	}
+	teapotErr, ok := err.(*errors.TeapotError)
+	if !ok || teapotErr.Type != errors.ErrorTypeSystem {
+		t.Errorf("Expected a system error, got %T: %v", err, err)
+	}
+	if !strings.Contains(err.Error(), "Writing apps") {
+		t.Errorf("Expected error to name the failing step, got %v", err)
+	}
+
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected no target directory after a failed generation, got %v", err)
+	}
+	assertNoStaging(t, parent)
+}
+
+func TestEngine_GenerateCancelled(t *testing.T) {
+	parent := t.TempDir()
+	target := filepath.Join(parent, "demo")
+	plan, err := BuildPlan(testProject())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	written := 0
+	err = NewEngine(testProject(), target).Generate(ctx, plan, func(event Event) {
+		if event.Type == EventFileWritten {
+			if written++; written == 3 {
+				cancel()
+			}
+		}
+	})
+	if err == nil {
+		t.Fatal("Expected a cancelled generation to fail")
+	}
+	if written != 3 {
+		t.Errorf("Expected generation to stop after the cancellation, wrote %d files", written)
+	}
+	if _, err := os.Stat(target); !os.IsNotExist(err) {
+		t.Errorf("Expected no target directory after a cancelled generation, got %v", err)
+	}
+	assertNoStaging(t, parent)
+}
+
+func TestEngine_GenerateIntoExistingDirectory(t *testing.T) {
+	parent := t.TempDir()
+	target := filepath.Join(parent, "demo")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "notes.txt"), []byte("keep"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewEngine(testProject(), target)
+	if err := engine.Run(nil); err == nil {
+		t.Fatal("Expected generation into a non-empty directory to abort by default")
+	}
+
+	engine.SetConflictPolicy(ConflictMerge)
+	if err := engine.Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+
+	for _, path := range []string{"notes.txt", "package.json", filepath.Join("apps", "web", "package.json")} {
+		if _, err := os.Stat(filepath.Join(target, path)); err != nil {
+			t.Errorf("Expected %s to exist: %v", path, err)
+		}
+	}
+	assertNoStaging(t, parent)
+}
+
+func TestEngine_GenerateMergeKeepsExistingFiles(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "demo")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "README.md"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewEngine(testProject(), target)
+	engine.SetConflictPolicy(ConflictMerge)
+	var skipped []string
+	err := engine.Run(func(event Event) {
+		if event.Type == EventFileSkipped {
+			skipped = append(skipped, event.Path)
+		}
+	})
+	if err != nil {
+		t.Fatalf("Expected merge to succeed, got error: %v", err)
+	}
+
+	if len(skipped) != 1 || skipped[0] != "README.md" {
+		t.Errorf("Expected only README.md to be skipped, got %v", skipped)
+	}
+	if content, _ := os.ReadFile(filepath.Join(target, "README.md")); string(content) != "mine" {
+		t.Errorf("Expected existing README.md to be kept, got %q", content)
+	}
+	if _, err := os.Stat(filepath.Join(target, "package.json")); err != nil {
+		t.Errorf("Expected missing files to be written: %v", err)
+	}
+}
+
+func TestEngine_GenerateOverwriteCreatesBackup(t *testing.T) {
+	parent := t.TempDir()
+	target := filepath.Join(parent, "demo")
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "README.md"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	engine := NewEngine(testProject(), target)
+	engine.SetConflictPolicy(ConflictOverwrite)
+	var backup string
+	err := engine.Run(func(event Event) {
+		if event.Type == EventBackupCreated {
+			backup = event.Path
+		}
+	})
+	if err != nil {
+		t.Fatalf("Expected overwrite to succeed, got error: %v", err)
+	}
+
+	if !strings.HasPrefix(backup, target+".backup-") {
+		t.Fatalf("Expected a backup next to the target, got %q", backup)
+	}
+	if content, _ := os.ReadFile(filepath.Join(backup, "README.md")); string(content) != "mine" {
+		t.Errorf("Expected the backup to hold the original README.md, got %q", content)
+	}
+	if content, _ := os.ReadFile(filepath.Join(target, "README.md")); string(content) == "mine" {
+		t.Error("Expected README.md to be regenerated")
+	}
+	if info, err := os.Stat(target); err != nil {
+		t.Errorf("Expected the project directory to exist: %v", err)
+	} else if info.Mode().Perm() != 0755 {
+		t.Errorf("Expected the regenerated project directory to have mode 0755, got %v", info.Mode().Perm())
+	}
+}
+
+func TestInspectTarget(t *testing.T) {
+	tests := []struct {
+		name     string
+		entries  []string
+		expected TargetState
+	}{
+		{"empty", nil, TargetEmpty},
+		{"files", []string{"notes.txt"}, TargetNonEmpty},
+		{"git", []string{".git/HEAD", "main.go"}, TargetGitRepo},
+		{"teapot", []string{".git/HEAD", "teapot.yml"}, TargetTeapotProject},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for _, entry := range tt.entries {
+				if err := writeFile(dir, File{Path: entry}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			state, err := InspectTarget(dir)
+			if err != nil {
+				t.Fatalf("Expected target to be inspected, got error: %v", err)
+			}
+			if state != tt.expected {
+				t.Errorf("Expected %v, got %v", tt.expected, state)
+			}
+		})
+	}
+
+	state, err := InspectTarget(filepath.Join(t.TempDir(), "missing"))
+	if err != nil || state != TargetMissing {
+		t.Errorf("Expected a missing target, got %v (%v)", state, err)
+	}
+}
+
+func TestParseConflictPolicy(t *testing.T) {
+	for _, policy := range ConflictPolicies {
+		if parsed, err := ParseConflictPolicy(string(policy)); err != nil || parsed != policy {
+			t.Errorf("Expected %s to parse, got %q (%v)", policy, parsed, err)
+		}
+	}
+	if _, err := ParseConflictPolicy("replace"); err == nil {
+		t.Error("Expected unknown policy to fail")
+	}
+}
+
+func TestMoveIntoPlace_RollsBack(t *testing.T) {
+	parent := t.TempDir()
+	staging := filepath.Join(parent, "staging")
+	target := filepath.Join(parent, "demo")
+	plan := &Plan{Steps: []Step{{Files: []File{
+		{Path: "README.md", Content: []byte("# demo")},
+		{Path: ".teapot/manifest.json", Content: []byte("{}")},
+		{Path: "apps/web/package.json", Content: []byte("{}")},
+	}}}}
+	for _, file := range plan.Steps[0].Files {
+		if err := writeFile(staging, file); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// A file named apps blocks the apps/ directory, so the second move fails
+	if err := os.MkdirAll(target, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(target, "apps"), []byte("blocker"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	writeProjectFile(t, target, ".teapot/manifest.json", "previous")
+
+	if err := moveIntoPlace(staging, target, []string{"README.md", ".teapot/manifest.json", "apps/web/package.json"}); err == nil {
+		t.Fatal("Expected moving into place to fail")
+	}
+	if _, err := os.Stat(filepath.Join(target, "README.md")); !os.IsNotExist(err) {
+		t.Error("Expected files moved before the failure to be removed")
+	}
+	if _, err := os.Stat(filepath.Join(target, "apps")); err != nil {
+		t.Errorf("Expected existing files to be kept: %v", err)
+	}
+	if manifest := readProjectFile(t, target, ".teapot/manifest.json"); manifest != "previous" {
+		t.Errorf("Expected replaced files to be restored, got %q", manifest)
+	}
+}
+
+func TestBuildPlan_SingleApp(t *testing.T) {
+	project := testProject()
+	project.Architecture = models.ArchitectureSingle
+	project.Applications = project.Applications[:1]
+
+	plan, err := BuildPlan(project)
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	paths := planPaths(plan)
+	for _, path := range []string{"package.json", "next.config.js", "tsconfig.json", "Dockerfile", ".github/workflows/ci.yml"} {
+		if !paths[path] {
+			t.Errorf("Expected plan to contain %s", path)
+		}
+	}
+	for path := range paths {
+		if strings.HasPrefix(path, "apps/") {
+			t.Errorf("Expected no apps/ folder for a single application, got %s", path)
+		}
+	}
+	for _, path := range []string{"turbo.json", "nx.json"} {
+		if paths[path] {
+			t.Errorf("Expected no %s for a single application", path)
+		}
+	}
+
+	var root packageJSON
+	var dockerfile string
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			switch file.Path {
+			case "package.json":
+				if err := json.Unmarshal(file.Content, &root); err != nil {
+					t.Fatalf("Expected valid package.json, got error: %v", err)
+				}
+			case "Dockerfile":
+				dockerfile = string(file.Content)
+			}
+		}
+	}
+	if root.Name != "test-project" || len(root.Workspaces) != 0 {
+		t.Errorf("Expected a non-workspace package named after the project, got %q with %v", root.Name, root.Workspaces)
+	}
+	if root.Scripts["build"] != "next build" || root.Scripts["prepare"] != "husky" {
+		t.Errorf("Expected app and tooling scripts in root package.json, got %v", root.Scripts)
+	}
+	if root.Dependencies["next"] == "" || root.DevDependencies["husky"] == "" {
+		t.Error("Expected app and tooling dependencies in root package.json")
+	}
+	if strings.Contains(dockerfile, "APP") {
+		t.Error("Expected the single application Dockerfile not to select an app")
+	}
+
+	project.Applications = testProject().Applications
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected more than one application to fail for a single application project")
+	}
+}
+
+func TestFormatSize(t *testing.T) {
+	tests := []struct {
+		size     int
+		expected string
+	}{
+		{0, "0 B"},
+		{512, "512 B"},
+		{1536, "1.5 KB"},
+		{3 * 1024 * 1024, "3.0 MB"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatSize(tt.size); result != tt.expected {
+			t.Errorf("Expected %d bytes to format as %q, got %q", tt.size, tt.expected, result)
+		}
+	}
+}
+
+func TestFormatPlanForDisplay(t *testing.T) {
+	plan, err := BuildPlan(testProject())
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	output := FormatPlanForDisplay(plan)
+	for _, expected := range []string{
+		"Base project initialized\n",
+		"README.md",
+		"(generated)",
+		"next/",
+		fmt.Sprintf("%d files", plan.FileCount()),
+	} {
+		if !strings.Contains(output, expected) {
+			t.Errorf("Expected plan output to contain %q, got:\n%s", expected, output)
+		}
+	}
+}
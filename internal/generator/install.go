@@ -0,0 +1,85 @@
+package generator
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+
+	"teapot/internal/errors"
+	"teapot/internal/models"
+)
+
+// installCommand creates the process that installs the dependencies of the project in dir.
+// It is a variable so tests can replace the package manager.
+var installCommand = func(ctx context.Context, pm models.PackageManagerType, dir string) *exec.Cmd {
+	args := strings.Fields(InstallCommand(pm))
+	cmd := exec.CommandContext(ctx, args[0], args[1:]...)
+	cmd.Dir = dir
+	return cmd
+}
+
+// InstallDependencies runs the package manager of a generated project in dir and
+// calls output with every line it prints on stdout or stderr. A failed install
+// is a recoverable error: the project is complete, so the install can be
+// retried or skipped and run by hand later. Cancelling ctx stops the package manager.
+func InstallDependencies(ctx context.Context, project models.ProjectConfig, dir string, output func(line string)) error {
+	pm := ProjectPackageManager(project)
+	command := InstallCommand(pm)
+
+	reader, writer := io.Pipe()
+	cmd := installCommand(ctx, pm, dir)
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	if err := cmd.Start(); err != nil {
+		return installError(fmt.Sprintf("failed to run %s: %v", command, err), err)
+	}
+
+	done := make(chan error, 1)
+	go func() {
+		err := cmd.Wait()
+		writer.Close()
+		done <- err
+	}()
+
+	scanner := bufio.NewScanner(reader)
+	scanner.Split(scanLogLines)
+	for scanner.Scan() {
+		if line := strings.TrimRight(scanner.Text(), " \t"); line != "" {
+			output(line)
+		}
+	}
+	// Drain what the scanner could not read so the process is never blocked on the pipe
+	io.Copy(io.Discard, reader)
+
+	if err := <-done; err != nil {
+		if exitErr, ok := err.(*exec.ExitError); ok {
+			return installError(fmt.Sprintf("%s exited with code %d", command, exitErr.ExitCode()), err)
+		}
+		return installError(fmt.Sprintf("%s failed: %v", command, err), err)
+	}
+	return nil
+}
+
+// installError creates the recoverable error of a failed install
+func installError(message string, cause error) *errors.TeapotError {
+	err := errors.NewSystemError(message, cause)
+	err.Recoverable = true
+	err.RecoveryAction = "Retry or skip the dependency install"
+	return err
+}
+
+// scanLogLines splits process output into lines at both newlines and carriage
+// returns, so progress lines redrawn in place arrive one by one.
+func scanLogLines(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
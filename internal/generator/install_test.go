@@ -0,0 +1,60 @@
+package generator
+
+import (
+	"context"
+	"os/exec"
+	"reflect"
+	"testing"
+
+	"teapot/internal/errors"
+	"teapot/internal/models"
+)
+
+// stubInstall replaces the package manager with a shell script
+func stubInstall(t *testing.T, script string) *models.PackageManagerType {
+	t.Helper()
+
+	var ran models.PackageManagerType
+	original := installCommand
+	installCommand = func(ctx context.Context, pm models.PackageManagerType, dir string) *exec.Cmd {
+		ran = pm
+		cmd := exec.CommandContext(ctx, "sh", "-c", script)
+		cmd.Dir = dir
+		return cmd
+	}
+	t.Cleanup(func() { installCommand = original })
+	return &ran
+}
+
+func TestInstallDependencies(t *testing.T) {
+	ran := stubInstall(t, `printf 'resolving\rfetching\n'; echo warning >&2; echo done`)
+
+	var lines []string
+	project := models.ProjectConfig{PackageManager: models.PackageManagerPnpm}
+	if err := InstallDependencies(context.Background(), project, t.TempDir(), func(line string) { lines = append(lines, line) }); err != nil {
+		t.Fatalf("Expected the install to succeed, got error: %v", err)
+	}
+	if *ran != models.PackageManagerPnpm {
+		t.Errorf("Expected pnpm to run, got %q", *ran)
+	}
+	if want := []string{"resolving", "fetching", "warning", "done"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("Expected output lines %q, got %q", want, lines)
+	}
+}
+
+func TestInstallDependencies_Failure(t *testing.T) {
+	stubInstall(t, "echo 'missing lockfile' >&2; exit 3")
+
+	var lines []string
+	err := InstallDependencies(context.Background(), models.ProjectConfig{}, t.TempDir(), func(line string) { lines = append(lines, line) })
+	teapotErr, ok := err.(*errors.TeapotError)
+	if !ok {
+		t.Fatalf("Expected a TeapotError, got %v", err)
+	}
+	if teapotErr.Message != "bun install exited with code 3" || !teapotErr.Recoverable {
+		t.Errorf("Expected a recoverable error for the exit code, got %q (recoverable %v)", teapotErr.Message, teapotErr.Recoverable)
+	}
+	if len(lines) != 1 || lines[0] != "missing lockfile" {
+		t.Errorf("Expected stderr to be streamed, got %q", lines)
+	}
+}
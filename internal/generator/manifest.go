@@ -0,0 +1,258 @@
+package generator
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// ManifestPath is where generated projects record the files teapot created.
+const ManifestPath = ".teapot/manifest.json"
+
+// BasePath is where generated projects keep every file as it was generated.
+// It is the common ancestor when upgrades merge template changes with local edits.
+const BasePath = ".teapot/base.json"
+
+// Manifest lists every file teapot created in a project, so later tooling can
+// tell untouched generated files from files the team has edited.
+type Manifest struct {
+	// ConfigVersion is the teapot.yml version the project was generated from
+	ConfigVersion string `json:"configVersion"`
+	// Files lists the generated files sorted by path
+	Files []ManifestFile `json:"files"`
+}
+
+// ManifestFile records how a single file was generated.
+type ManifestFile struct {
+	// Path is the slash-separated path relative to the project root
+	Path string `json:"path"`
+	// Template is the ID of the template the file was rendered from, empty for generated files
+	Template string `json:"template,omitempty"`
+	// TemplateVersion identifies the revision of the template and its partials
+	TemplateVersion string `json:"templateVersion,omitempty"`
+	// Hash is the content hash of the file as generated
+	Hash string `json:"hash"`
+}
+
+// HashContent returns the content hash recorded in the manifest.
+func HashContent(content []byte) string {
+	sum := sha256.Sum256(content)
+	return "sha256:" + hex.EncodeToString(sum[:])
+}
+
+// Matches reports whether content is unchanged since the file was generated.
+func (f ManifestFile) Matches(content []byte) bool {
+	return f.Hash == HashContent(content)
+}
+
+// Lookup returns the manifest entry for a path.
+func (m *Manifest) Lookup(path string) (ManifestFile, bool) {
+	for _, file := range m.Files {
+		if file.Path == path {
+			return file, true
+		}
+	}
+	return ManifestFile{}, false
+}
+
+// LoadManifest reads the manifest of a generated project.
+func LoadManifest(projectDir string) (*Manifest, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(ManifestPath)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	var manifest Manifest
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestPath, err)
+	}
+	return &manifest, nil
+}
+
+// Base holds the content of every generated file, keyed by path.
+type Base struct {
+	// Files maps slash-separated paths to the content teapot generated
+	Files map[string]string `json:"files"`
+}
+
+// LoadBase reads the generated content recorded in a project.
+func LoadBase(projectDir string) (*Base, error) {
+	data, err := os.ReadFile(filepath.Join(projectDir, filepath.FromSlash(BasePath)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read generated content: %w", err)
+	}
+
+	var base Base
+	if err := json.Unmarshal(data, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", BasePath, err)
+	}
+	if base.Files == nil {
+		base.Files = make(map[string]string)
+	}
+	return &base, nil
+}
+
+// newManifest records every file of the given steps.
+func newManifest(steps []Step) Manifest {
+	manifest := Manifest{ConfigVersion: teapotYAMLVersion, Files: []ManifestFile{}}
+	for _, step := range steps {
+		for _, file := range step.Files {
+			manifest.Files = append(manifest.Files, ManifestFile{
+				Path:            file.Path,
+				Template:        file.Template,
+				TemplateVersion: file.TemplateVersion,
+				Hash:            HashContent(file.Content),
+			})
+		}
+	}
+	sortManifest(&manifest)
+	return manifest
+}
+
+// newBase records the content of every file of the given steps.
+func newBase(steps []Step) Base {
+	base := Base{Files: make(map[string]string)}
+	for _, step := range steps {
+		for _, file := range step.Files {
+			base.Files[file.Path] = string(file.Content)
+		}
+	}
+	return base
+}
+
+// manifestStep emits the manifest and generated content for every file planned before it.
+func manifestStep(steps []Step) (Step, error) {
+	step := Step{Name: "Manifest recorded", Description: "Recording generated files"}
+
+	manifest, err := jsonFile(ManifestPath, newManifest(steps))
+	if err != nil {
+		return step, err
+	}
+	base, err := jsonFile(BasePath, newBase(steps))
+	if err != nil {
+		return step, err
+	}
+	step.Files = append(step.Files, manifest, base)
+	return step, nil
+}
+
+// isMetadata reports whether a planned file is one of teapot's own records.
+func isMetadata(path string) bool {
+	return path == ManifestPath || path == BasePath
+}
+
+// mergeMetadata updates teapot's records of an existing project after a merge.
+// Entries of the files written now replace those recorded by earlier runs,
+// written paths the plan no longer generates lose their entries, and files kept
+// as they were keep their earlier entries.
+func mergeMetadata(target string, file File, written map[string]bool) (File, error) {
+	var content []byte
+	var err error
+	switch file.Path {
+	case ManifestPath:
+		content, err = mergeManifest(target, file.Content, written)
+	case BasePath:
+		content, err = mergeBase(target, file.Content, written)
+	default:
+		return file, fmt.Errorf("%s is not a teapot record", file.Path)
+	}
+	if err != nil {
+		return file, err
+	}
+	file.Content = content
+	return file, nil
+}
+
+// mergeManifest merges the planned manifest into the existing one.
+func mergeManifest(target string, planned []byte, written map[string]bool) ([]byte, error) {
+	var manifest Manifest
+	if err := json.Unmarshal(planned, &manifest); err != nil {
+		return nil, fmt.Errorf("failed to parse planned manifest: %w", err)
+	}
+
+	entries := make(map[string]ManifestFile)
+	existing, err := LoadManifest(target)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if existing != nil {
+		for _, entry := range existing.Files {
+			entries[entry.Path] = entry
+		}
+	}
+	inPlan := make(map[string]bool)
+	for _, entry := range manifest.Files {
+		inPlan[entry.Path] = true
+		if written[entry.Path] {
+			entries[entry.Path] = entry
+		}
+	}
+	for path := range written {
+		if !inPlan[path] {
+			delete(entries, path)
+		}
+	}
+
+	merged := Manifest{ConfigVersion: manifest.ConfigVersion, Files: []ManifestFile{}}
+	for _, entry := range entries {
+		merged.Files = append(merged.Files, entry)
+	}
+	sortManifest(&merged)
+	return marshalJSON(merged)
+}
+
+// mergeBase merges the planned generated content into the existing record.
+func mergeBase(target string, planned []byte, written map[string]bool) ([]byte, error) {
+	var base Base
+	if err := json.Unmarshal(planned, &base); err != nil {
+		return nil, fmt.Errorf("failed to parse planned generated content: %w", err)
+	}
+
+	merged := Base{Files: make(map[string]string)}
+	existing, err := LoadBase(target)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, err
+	}
+	if existing != nil {
+		merged.Files = existing.Files
+	}
+	for path := range written {
+		if content, ok := base.Files[path]; ok {
+			merged.Files[path] = content
+		} else {
+			delete(merged.Files, path)
+		}
+	}
+	return marshalJSON(merged)
+}
+
+// sortManifest orders the manifest entries by path so manifests diff cleanly.
+func sortManifest(manifest *Manifest) {
+	sort.Slice(manifest.Files, func(i, j int) bool {
+		return manifest.Files[i].Path < manifest.Files[j].Path
+	})
+}
+
+// templateVersion hashes a template together with its partials, so the version
+// changes whenever anything that affects the rendered output changes.
+func templateVersion(names []string) (string, error) {
+	hash := sha256.New()
+	for _, name := range names {
+		text, err := templateFS.ReadFile(name)
+		if err != nil {
+			return "", fmt.Errorf("failed to read template %s: %w", name, err)
+		}
+		hash.Write([]byte(name))
+		hash.Write(text)
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:12], nil
+}
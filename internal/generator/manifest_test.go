@@ -0,0 +1,113 @@
+package generator
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestBuildPlan_Manifest(t *testing.T) {
+	plan, err := BuildPlan(testProject())
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	last := plan.Steps[len(plan.Steps)-1]
+	if len(last.Files) != 2 || last.Files[0].Path != ManifestPath || last.Files[1].Path != BasePath {
+		t.Fatalf("Expected the manifest and generated content to be the last planned files, got %v", last.Files)
+	}
+
+	var manifest Manifest
+	if err := json.Unmarshal(last.Files[0].Content, &manifest); err != nil {
+		t.Fatalf("Expected valid manifest JSON, got error: %v", err)
+	}
+	if manifest.ConfigVersion != teapotYAMLVersion {
+		t.Errorf("Expected config version %q, got %q", teapotYAMLVersion, manifest.ConfigVersion)
+	}
+	if len(manifest.Files) != plan.FileCount()-2 {
+		t.Errorf("Expected %d manifest entries, got %d", plan.FileCount()-2, len(manifest.Files))
+	}
+
+	var base Base
+	if err := json.Unmarshal(last.Files[1].Content, &base); err != nil {
+		t.Fatalf("Expected valid generated content JSON, got error: %v", err)
+	}
+
+	for _, step := range plan.Steps[:len(plan.Steps)-1] {
+		for _, file := range step.Files {
+			entry, ok := manifest.Lookup(file.Path)
+			if !ok {
+				t.Errorf("Expected manifest entry for %s", file.Path)
+				continue
+			}
+			if !entry.Matches(file.Content) {
+				t.Errorf("Expected manifest hash of %s to match its content", file.Path)
+			}
+			if entry.Template != file.Template {
+				t.Errorf("Expected template %q for %s, got %q", file.Template, file.Path, entry.Template)
+			}
+			if file.Template != "" && len(entry.TemplateVersion) != 12 {
+				t.Errorf("Expected a template version for %s, got %q", file.Path, entry.TemplateVersion)
+			}
+			if base.Files[file.Path] != string(file.Content) {
+				t.Errorf("Expected generated content of %s to be recorded", file.Path)
+			}
+		}
+	}
+}
+
+func TestEngine_ManifestMerge(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "demo")
+	engine := NewEngine(testProject(), target)
+	if err := engine.Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+
+	original, err := LoadManifest(target)
+	if err != nil {
+		t.Fatalf("Expected manifest to load, got error: %v", err)
+	}
+
+	// Edit one file and delete another, then merge the project again
+	if err := os.WriteFile(filepath.Join(target, "README.md"), []byte("edited"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Remove(filepath.Join(target, "package.json")); err != nil {
+		t.Fatal(err)
+	}
+	engine.SetConflictPolicy(ConflictMerge)
+	if err := engine.Run(nil); err != nil {
+		t.Fatalf("Expected merge to succeed, got error: %v", err)
+	}
+
+	merged, err := LoadManifest(target)
+	if err != nil {
+		t.Fatalf("Expected merged manifest to load, got error: %v", err)
+	}
+	if len(merged.Files) != len(original.Files) {
+		t.Errorf("Expected %d manifest entries after merge, got %d", len(original.Files), len(merged.Files))
+	}
+
+	readme, _ := merged.Lookup("README.md")
+	if readme.Matches([]byte("edited")) {
+		t.Error("Expected the edited README.md to differ from its manifest entry")
+	}
+	base, err := LoadBase(target)
+	if err != nil {
+		t.Fatalf("Expected generated content to load, got error: %v", err)
+	}
+	if base.Files["README.md"] == "edited" || base.Files["package.json"] == "" {
+		t.Error("Expected generated content to keep kept files and record regenerated ones")
+	}
+	content, _ := os.ReadFile(filepath.Join(target, "package.json"))
+	if entry, ok := merged.Lookup("package.json"); !ok || !entry.Matches(content) {
+		t.Error("Expected the regenerated package.json to match its manifest entry")
+	}
+}
+
+func TestLoadManifest_Missing(t *testing.T) {
+	if _, err := LoadManifest(t.TempDir()); err == nil {
+		t.Error("Expected a missing manifest to fail")
+	}
+}
@@ -0,0 +1,147 @@
+package generator
+
+import (
+	"strings"
+)
+
+// Conflict markers written where local edits and template changes overlap.
+const (
+	conflictStart  = "<<<<<<< local\n"
+	conflictMiddle = "=======\n"
+	conflictEnd    = ">>>>>>> teapot upgrade\n"
+)
+
+// maxMergeCells bounds the line comparison table; larger files are treated as
+// a single conflict rather than compared line by line.
+const maxMergeCells = 4_000_000
+
+// Merge3 merges the changes from base to local and from base to upstream line
+// by line. Changes on only one side are applied; overlapping changes that differ
+// are written between conflict markers. It returns the merged content and the
+// number of conflicting hunks.
+func Merge3(base, local, upstream string) (string, int) {
+	baseLines := splitLines(base)
+	localLines := splitLines(local)
+	upstreamLines := splitLines(upstream)
+
+	localMatch := matchLines(baseLines, localLines)
+	upstreamMatch := matchLines(baseLines, upstreamLines)
+
+	var merged []string
+	conflicts := 0
+	b, l, u := 0, 0, 0
+	for {
+		// The next sync point is a base line both sides kept unchanged
+		k := b
+		for k < len(baseLines) && (localMatch[k] < 0 || upstreamMatch[k] < 0) {
+			k++
+		}
+		lEnd, uEnd := len(localLines), len(upstreamLines)
+		if k < len(baseLines) {
+			lEnd, uEnd = localMatch[k], upstreamMatch[k]
+		}
+
+		baseHunk, localHunk, upstreamHunk := baseLines[b:k], localLines[l:lEnd], upstreamLines[u:uEnd]
+		switch {
+		case equalLines(localHunk, baseHunk):
+			merged = append(merged, upstreamHunk...)
+		case equalLines(upstreamHunk, baseHunk), equalLines(localHunk, upstreamHunk):
+			merged = append(merged, localHunk...)
+		default:
+			conflicts++
+			merged = append(merged, conflictStart)
+			merged = append(merged, terminated(localHunk)...)
+			merged = append(merged, conflictMiddle)
+			merged = append(merged, terminated(upstreamHunk)...)
+			merged = append(merged, conflictEnd)
+		}
+
+		if k == len(baseLines) {
+			break
+		}
+		merged = append(merged, baseLines[k])
+		b, l, u = k+1, lEnd+1, uEnd+1
+	}
+
+	return strings.Join(merged, ""), conflicts
+}
+
+// splitLines splits content into lines that keep their line endings, so joining
+// them restores the content exactly.
+func splitLines(content string) []string {
+	if content == "" {
+		return nil
+	}
+	lines := strings.SplitAfter(content, "\n")
+	if lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// matchLines computes a longest common subsequence of a and b and returns, for
+// every line of a, the index of its matching line in b or -1.
+func matchLines(a, b []string) []int {
+	match := make([]int, len(a))
+	for i := range match {
+		match[i] = -1
+	}
+	if len(a) == 0 || len(b) == 0 || len(a)*len(b) > maxMergeCells {
+		return match
+	}
+
+	// lengths[i][j] is the LCS length of a[i:] and b[j:]
+	lengths := make([][]int32, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int32, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		switch {
+		case a[i] == b[j]:
+			match[i] = j
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			i++
+		default:
+			j++
+		}
+	}
+	return match
+}
+
+// terminated returns lines whose last line ends with a newline, so a conflict
+// marker after them starts on its own line.
+func terminated(lines []string) []string {
+	if len(lines) == 0 || strings.HasSuffix(lines[len(lines)-1], "\n") {
+		return lines
+	}
+	fixed := append([]string{}, lines...)
+	fixed[len(fixed)-1] += "\n"
+	return fixed
+}
+
+// equalLines reports whether two line slices are identical.
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,74 @@
+package generator
+
+import (
+	"testing"
+)
+
+func TestMerge3(t *testing.T) {
+	base := "one\ntwo\nthree\nfour\n"
+
+	tests := []struct {
+		name      string
+		local     string
+		upstream  string
+		expected  string
+		conflicts int
+	}{
+		{
+			name:     "unchanged",
+			local:    base,
+			upstream: base,
+			expected: base,
+		},
+		{
+			name:     "upstream change only",
+			local:    base,
+			upstream: "one\n2\nthree\nfour\n",
+			expected: "one\n2\nthree\nfour\n",
+		},
+		{
+			name:     "local change only",
+			local:    "one\ntwo\nthree\n4\n",
+			upstream: base,
+			expected: "one\ntwo\nthree\n4\n",
+		},
+		{
+			name:     "separate changes",
+			local:    "zero\none\ntwo\nthree\nfour\n",
+			upstream: "one\ntwo\nthree\nfour\nfive\n",
+			expected: "zero\none\ntwo\nthree\nfour\nfive\n",
+		},
+		{
+			name:     "same change on both sides",
+			local:    "one\n2\nthree\nfour\n",
+			upstream: "one\n2\nthree\nfour\n",
+			expected: "one\n2\nthree\nfour\n",
+		},
+		{
+			name:      "overlapping changes",
+			local:     "one\nlocal\nthree\nfour\n",
+			upstream:  "one\nupstream\nthree\nfour\n",
+			expected:  "one\n<<<<<<< local\nlocal\n=======\nupstream\n>>>>>>> teapot upgrade\nthree\nfour\n",
+			conflicts: 1,
+		},
+		{
+			name:      "missing trailing newline",
+			local:     "one\ntwo\nthree\nlocal",
+			upstream:  "one\ntwo\nthree\nupstream",
+			expected:  "one\ntwo\nthree\n<<<<<<< local\nlocal\n=======\nupstream\n>>>>>>> teapot upgrade\n",
+			conflicts: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			merged, conflicts := Merge3(base, tt.local, tt.upstream)
+			if merged != tt.expected {
+				t.Errorf("Expected merged content %q, got %q", tt.expected, merged)
+			}
+			if conflicts != tt.conflicts {
+				t.Errorf("Expected %d conflicts, got %d", tt.conflicts, conflicts)
+			}
+		})
+	}
+}
@@ -0,0 +1,207 @@
+package generator
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"teapot/internal/errors"
+
+	"gopkg.in/yaml.v3"
+)
+
+// migration rewrites a teapot.yml document from one version to the next.
+type migration struct {
+	// from is the version the migration reads
+	from string
+	// to is the version the migration writes
+	to string
+	// migrate rewrites the root mapping in place and describes every deprecated
+	// setting it moved; it fails with a *migrationConflict when moving a setting
+	// would drop a value
+	migrate func(root *yaml.Node) ([]deprecation, error)
+}
+
+// deprecation is a deprecated setting found by a migration.
+type deprecation struct {
+	// node is the setting's key, for positions in diagnostics
+	node *yaml.Node
+	// err describes the setting and its replacement
+	err *errors.TeapotError
+}
+
+// migrationConflict is a deprecated setting that cannot be moved because its
+// replacement is already set to a different value.
+type migrationConflict struct {
+	// node is the deprecated setting's key, for positions in diagnostics
+	node *yaml.Node
+	// err describes both values
+	err *errors.TeapotError
+	// suggestion tells how to resolve the conflict
+	suggestion string
+}
+
+func (c *migrationConflict) Error() string {
+	return fmt.Sprintf("line %d: %s (%s)", c.node.Line, c.err.Message, c.suggestion)
+}
+
+// migrations is the chain of teapot.yml versions, oldest first. The last
+// migration writes teapotYAMLVersion.
+var migrations = []migration{
+	{from: "1.0", to: "2.0", migrate: migrateArchitectureToProject},
+}
+
+// ConfigMigration is the result of bringing teapot.yml content up to the current version.
+type ConfigMigration struct {
+	// From is the version the content was written in
+	From string
+	// To is the version the content is in now
+	To string
+	// Content is the content in the current version; it is the original content
+	// when no migration was needed
+	Content []byte
+	// Warnings describes every deprecated setting that was migrated
+	Warnings []*errors.TeapotError
+}
+
+// Migrated reports whether the content was written in an older version.
+func (m *ConfigMigration) Migrated() bool {
+	return m.From != m.To
+}
+
+// MigrateTeapotYAML runs teapot.yml content through every migration from its
+// version to the current one. Comments and key order are kept.
+func MigrateTeapotYAML(data []byte) (*ConfigMigration, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+	if doc.Kind != yaml.DocumentNode || len(doc.Content) == 0 || doc.Content[0].Kind != yaml.MappingNode {
+		// Nothing to migrate; decoding reports what is wrong with the content
+		return &ConfigMigration{From: teapotYAMLVersion, To: teapotYAMLVersion, Content: data}, nil
+	}
+
+	result := &ConfigMigration{To: teapotYAMLVersion, Content: data}
+	from, deprecations, err := migrateDocument(doc.Content[0])
+	if err != nil {
+		return nil, err
+	}
+	result.From = from
+	for _, deprecated := range deprecations {
+		result.Warnings = append(result.Warnings, deprecated.err)
+	}
+	if !result.Migrated() {
+		return result, nil
+	}
+
+	var buf bytes.Buffer
+	encoder := yaml.NewEncoder(&buf)
+	encoder.SetIndent(4)
+	if err := encoder.Encode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to write migrated YAML: %w", err)
+	}
+	result.Content = buf.Bytes()
+	return result, nil
+}
+
+// migrateDocument runs the root mapping of a teapot.yml document through every
+// migration from its version to the current one and returns the version it was
+// written in. Migrations move nodes rather than copy them, so they keep their
+// original line and column.
+func migrateDocument(root *yaml.Node) (string, []deprecation, error) {
+	version := mappingValue(root, "version")
+	if version == nil {
+		return "", nil, fmt.Errorf("teapot.yml has no version (supported: %s)", strings.Join(SupportedTeapotYAMLVersions(), ", "))
+	}
+	from := version.Value
+	if from == teapotYAMLVersion {
+		return from, nil, nil
+	}
+
+	var deprecations []deprecation
+	current := from
+	for _, m := range migrations {
+		if m.from != current {
+			continue
+		}
+		moved, err := m.migrate(root)
+		if err != nil {
+			return from, nil, err
+		}
+		deprecations = append(deprecations, moved...)
+		current = m.to
+	}
+	if current != teapotYAMLVersion {
+		return from, nil, fmt.Errorf("unsupported teapot.yml version %q (supported: %s)", from, strings.Join(SupportedTeapotYAMLVersions(), ", "))
+	}
+
+	version.Value = teapotYAMLVersion
+	version.Style = yaml.DoubleQuotedStyle
+	return from, deprecations, nil
+}
+
+// SupportedTeapotYAMLVersions lists every teapot.yml version this release reads, oldest first.
+func SupportedTeapotYAMLVersions() []string {
+	versions := make([]string, 0, len(migrations)+1)
+	for _, m := range migrations {
+		versions = append(versions, m.from)
+	}
+	return append(versions, teapotYAMLVersion)
+}
+
+// migrateArchitectureToProject moves the top-level architecture (1.0) into the
+// project section (2.0), next to the rest of the project settings. A file that
+// already sets a different project.architecture is a conflict, since one of the
+// values would be dropped.
+func migrateArchitectureToProject(root *yaml.Node) ([]deprecation, error) {
+	key, value := removeMappingKey(root, "architecture")
+	if key == nil {
+		return nil, nil
+	}
+
+	project := mappingValue(root, "project")
+	if project == nil {
+		project = &yaml.Node{Kind: yaml.MappingNode, Tag: "!!map"}
+		root.Content = append(root.Content, &yaml.Node{Kind: yaml.ScalarNode, Tag: "!!str", Value: "project"}, project)
+	}
+	if project.Kind == yaml.MappingNode {
+		current := mappingValue(project, "architecture")
+		if current == nil {
+			project.Content = append(project.Content, key, value)
+		} else if current.Value != value.Value {
+			return nil, &migrationConflict{
+				node: key,
+				err: errors.NewValidationError(fmt.Sprintf("the top-level architecture %q conflicts with project.architecture %q",
+					value.Value, current.Value), nil),
+				suggestion: "remove the top-level architecture setting or set both to the same value",
+			}
+		}
+	}
+
+	return []deprecation{{
+		node: key,
+		err:  errors.NewDeprecationError("the top-level architecture setting is deprecated, use project.architecture", nil),
+	}}, nil
+}
+
+// mappingValue returns the value of key in a mapping node, or nil.
+func mappingValue(mapping *yaml.Node, key string) *yaml.Node {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			return mapping.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// removeMappingKey removes key from a mapping node and returns its key and value nodes.
+func removeMappingKey(mapping *yaml.Node, key string) (*yaml.Node, *yaml.Node) {
+	for i := 0; i+1 < len(mapping.Content); i += 2 {
+		if mapping.Content[i].Value == key {
+			k, v := mapping.Content[i], mapping.Content[i+1]
+			mapping.Content = append(mapping.Content[:i], mapping.Content[i+2:]...)
+			return k, v
+		}
+	}
+	return nil, nil
+}
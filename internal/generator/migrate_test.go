@@ -0,0 +1,128 @@
+package generator
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"teapot/internal/errors"
+	"teapot/internal/models"
+)
+
+// teapotYAMLV1 is a teapot.yml as written by the first release
+const teapotYAMLV1 = `version: "1.0"
+project:
+    name: legacy
+    description: ""
+# How the repository is organized
+architecture: turborepo
+applications:
+    - id: app-next
+      name: web
+      type: next
+      options: {}
+devTools:
+    linting: biome
+    typescript: true
+    husky: false
+    lintStaged: false
+infrastructure:
+    docker: false
+    dockerCompose: false
+    pulumi: false
+    terraform: false
+ciPipeline:
+    provider: skip
+    features: []
+aiTools:
+    editor: ""
+    extensions: []
+`
+
+func TestMigrateTeapotYAML(t *testing.T) {
+	migration, err := MigrateTeapotYAML([]byte(teapotYAMLV1))
+	if err != nil {
+		t.Fatalf("Expected migration to succeed, got error: %v", err)
+	}
+	if !migration.Migrated() || migration.From != "1.0" {
+		t.Errorf("Expected a migration from 1.0, got %q", migration.From)
+	}
+
+	content := string(migration.Content)
+	for _, expected := range []string{`version: "` + teapotYAMLVersion + `"`, "    # How the repository is organized\n    architecture: turborepo\n"} {
+		if !strings.Contains(content, expected) {
+			t.Errorf("Expected migrated content to contain %q, got:\n%s", expected, content)
+		}
+	}
+
+	if len(migration.Warnings) != 1 || !strings.Contains(migration.Warnings[0].Message, "project.architecture") {
+		t.Errorf("Expected a warning for the moved architecture, got %v", migration.Warnings)
+	}
+
+	// Migrating the result again is a no-op
+	again, err := MigrateTeapotYAML(migration.Content)
+	if err != nil || again.Migrated() || string(again.Content) != content || len(again.Warnings) != 0 {
+		t.Errorf("Expected migrated content to be current, got %+v (error: %v)", again, err)
+	}
+}
+
+func TestParseTeapotYAML_OlderVersion(t *testing.T) {
+	project, warnings, err := parseTeapotYAML([]byte(teapotYAMLV1))
+	if err != nil {
+		t.Fatalf("Expected a 1.0 teapot.yml to load, got error: %v", err)
+	}
+	if project.Architecture != models.ArchitectureTurborepo {
+		t.Errorf("Expected the architecture to be migrated, got %q", project.Architecture)
+	}
+	if len(warnings) != 2 || !strings.Contains(warnings[1].Message, "teapot migrate-config") {
+		t.Fatalf("Expected the migrated settings and the version to be reported, got %v", warnings)
+	}
+	for _, warning := range warnings {
+		if warning.Type != errors.ErrorTypeDeprecation {
+			t.Errorf("Expected a deprecation warning, got %v", warning)
+		}
+	}
+
+	// A migrated file parses into the same project as a freshly written one
+	current, err := GenerateTeapotYAML(project)
+	if err != nil {
+		t.Fatalf("Expected YAML to generate, got error: %v", err)
+	}
+	reloaded, warnings, err := parseTeapotYAML([]byte(current))
+	if err != nil || len(warnings) != 0 {
+		t.Fatalf("Expected current teapot.yml to load without warnings, got %v (error: %v)", warnings, err)
+	}
+	if !reflect.DeepEqual(reloaded, project) {
+		t.Errorf("Expected %+v, got %+v", project, reloaded)
+	}
+}
+
+func TestMigrateTeapotYAML_Unsupported(t *testing.T) {
+	for _, content := range []string{"version: \"0.9\"\n", "project:\n    name: unversioned\n"} {
+		if _, err := MigrateTeapotYAML([]byte(content)); err == nil || !strings.Contains(err.Error(), "supported: 1.0, "+teapotYAMLVersion) {
+			t.Errorf("Expected %q to be rejected with the supported versions, got %v", content, err)
+		}
+	}
+}
+
+func TestMigrateTeapotYAML_ArchitectureConflict(t *testing.T) {
+	content := strings.Replace(teapotYAMLV1, "    description: \"\"\n", "    description: \"\"\n    architecture: single\n", 1)
+	if _, err := MigrateTeapotYAML([]byte(content)); err == nil || !strings.Contains(err.Error(), `"turborepo" conflicts with project.architecture "single"`) {
+		t.Errorf("Expected the conflicting architectures to fail the migration, got %v", err)
+	}
+
+	diagnostics := ValidateTeapotYAML([]byte(content), testSchemaChoices())
+	if len(diagnostics) != 1 || diagnostics[0].IsWarning() || diagnostics[0].Line != 7 || diagnostics[0].Column != 1 {
+		t.Fatalf("Expected one error at the top-level architecture, got %+v", diagnostics)
+	}
+
+	// The same value in both places loses nothing
+	content = strings.Replace(teapotYAMLV1, "    description: \"\"\n", "    description: \"\"\n    architecture: turborepo\n", 1)
+	migration, err := MigrateTeapotYAML([]byte(content))
+	if err != nil || len(migration.Warnings) != 1 {
+		t.Fatalf("Expected equal architectures to migrate with a warning, got %+v (error: %v)", migration, err)
+	}
+	if strings.Count(string(migration.Content), "architecture:") != 1 {
+		t.Errorf("Expected a single architecture setting, got:\n%s", migration.Content)
+	}
+}
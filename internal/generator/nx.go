@@ -0,0 +1,153 @@
+package generator
+
+import (
+	"strings"
+
+	"teapot/internal/models"
+)
+
+// nxSchemaPath is the nx.json schema shipped with the nx package
+const nxSchemaPath = "./node_modules/nx/schemas/nx-schema.json"
+
+// nxTargetOrder is the order of the targets emitted into each project.json
+var nxTargetOrder = []string{"build", "dev", "start", "lint", "test"}
+
+// nxConfig models the subset of nx.json emitted by the generator.
+type nxConfig struct {
+	Schema         string                  `json:"$schema"`
+	DefaultBase    string                  `json:"defaultBase"`
+	NamedInputs    map[string][]string     `json:"namedInputs"`
+	TargetDefaults map[string]nxTargetSpec `json:"targetDefaults"`
+}
+
+// nxProject models a project.json file for a single application.
+type nxProject struct {
+	Name                 string                  `json:"name"`
+	Schema               string                  `json:"$schema"`
+	ProjectType          string                  `json:"projectType"`
+	SourceRoot           string                  `json:"sourceRoot"`
+	Tags                 []string                `json:"tags"`
+	ImplicitDependencies []string                `json:"implicitDependencies,omitempty"`
+	Targets              map[string]nxTargetSpec `json:"targets"`
+}
+
+// nxTargetSpec models a target in project.json or a target default in nx.json.
+type nxTargetSpec struct {
+	Executor   string            `json:"executor,omitempty"`
+	Options    map[string]string `json:"options,omitempty"`
+	DependsOn  []string          `json:"dependsOn,omitempty"`
+	Inputs     []string          `json:"inputs,omitempty"`
+	Outputs    []string          `json:"outputs,omitempty"`
+	Cache      *bool             `json:"cache,omitempty"`
+	Continuous bool              `json:"continuous,omitempty"`
+}
+
+// buildNxConfig returns the nx.json for a project: named inputs shared by every
+// project and target defaults that make Nx infer the task graph from dependencies.
+func buildNxConfig(project models.ProjectConfig) nxConfig {
+	cached, uncached := true, false
+
+	sharedGlobals := []string{"{workspaceRoot}/package.json"}
+	if project.DevTools.TypeScript {
+		sharedGlobals = append(sharedGlobals, "{workspaceRoot}/tsconfig.base.json")
+	}
+
+	lintInputs := []string{"default"}
+	switch project.DevTools.Linting {
+	case "prettier-eslint":
+		lintInputs = append(lintInputs, "{workspaceRoot}/eslint.config.mjs")
+	case "biome":
+		lintInputs = append(lintInputs, "{workspaceRoot}/biome.json")
+	}
+
+	return nxConfig{
+		Schema:      nxSchemaPath,
+		DefaultBase: "main",
+		NamedInputs: map[string][]string{
+			"default":       {"{projectRoot}/**/*", "sharedGlobals"},
+			"production":    {"default", "!{projectRoot}/**/*.test.[jt]s?(x)", "!{projectRoot}/**/*.spec.[jt]s?(x)"},
+			"sharedGlobals": sharedGlobals,
+		},
+		TargetDefaults: map[string]nxTargetSpec{
+			"build": {DependsOn: []string{"^build"}, Inputs: []string{"production", "^production"}, Cache: &cached},
+			"dev":   {Cache: &uncached, Continuous: true},
+			"lint":  {Inputs: lintInputs, Cache: &cached},
+			"test":  {DependsOn: []string{"^build"}, Inputs: []string{"default", "^production"}, Cache: &cached},
+		},
+	}
+}
+
+// buildNxProject returns the project.json for an application. Client apps declare
+// every server app as an implicit dependency so their builds run after the APIs.
+func buildNxProject(project models.ProjectConfig, app models.Application) nxProject {
+	dir := AppDir(project, app)
+
+	sourceRoot := dir
+	if folder := AppSourceFolder(app.Type); folder != "" {
+		sourceRoot = dir + "/" + folder
+	}
+
+	scope := "client"
+	if IsServerApp(app.Type) {
+		scope = "server"
+	}
+
+	nx := nxProject{
+		Name:        AppFolderName(app),
+		Schema:      "../../node_modules/nx/schemas/project-schema.json",
+		ProjectType: "application",
+		SourceRoot:  sourceRoot,
+		Tags:        []string{"type:" + string(app.Type), "scope:" + scope},
+		Targets:     make(map[string]nxTargetSpec),
+	}
+
+	if !IsServerApp(app.Type) {
+		for _, other := range project.Applications {
+			if IsServerApp(other.Type) {
+				nx.ImplicitDependencies = append(nx.ImplicitDependencies, AppFolderName(other))
+			}
+		}
+	}
+
+	scripts := appScripts(project, app)
+	for _, target := range nxTargetOrder {
+		if _, ok := scripts[target]; !ok {
+			continue
+		}
+		spec := nxTargetSpec{
+			Executor: "nx:run-script",
+			Options:  map[string]string{"script": target},
+		}
+		switch target {
+		case "build":
+			spec.Outputs = nxOutputs(app)
+		case "dev", "start":
+			spec.Continuous = true
+		}
+		nx.Targets[target] = spec
+	}
+	return nx
+}
+
+// nxOutputs converts an application's build outputs into Nx output paths.
+func nxOutputs(app models.Application) []string {
+	var outputs []string
+	for _, output := range appBuildOutputs(app) {
+		if strings.HasPrefix(output, "!") {
+			continue
+		}
+		outputs = append(outputs, "{projectRoot}/"+strings.TrimSuffix(output, "/**"))
+	}
+	return outputs
+}
+
+// nxScripts returns the root package.json scripts that run each target across projects.
+func nxScripts(project models.ProjectConfig) map[string]string {
+	scripts := make(map[string]string)
+	for _, target := range workspaceTasks {
+		if appsHaveScript(project, target) {
+			scripts[target] = "nx run-many -t " + target
+		}
+	}
+	return scripts
+}
@@ -0,0 +1,97 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+// nxTestProject returns the test project configured for Nx
+func nxTestProject() models.ProjectConfig {
+	project := testProject()
+	project.Architecture = models.ArchitectureNx
+	return project
+}
+
+func TestBuildNxConfig(t *testing.T) {
+	config := buildNxConfig(nxTestProject())
+
+	for _, input := range []string{"default", "production", "sharedGlobals"} {
+		if _, ok := config.NamedInputs[input]; !ok {
+			t.Errorf("Expected named input %s", input)
+		}
+	}
+
+	build := config.TargetDefaults["build"]
+	if len(build.DependsOn) != 1 || build.DependsOn[0] != "^build" {
+		t.Errorf("Expected build to depend on dependency builds, got %v", build.DependsOn)
+	}
+	if build.Cache == nil || !*build.Cache {
+		t.Error("Expected build to be cached")
+	}
+}
+
+func TestBuildNxProject(t *testing.T) {
+	project := nxTestProject()
+
+	web := buildNxProject(project, project.Applications[0])
+	if web.Name != "web" || web.SourceRoot != "apps/web/src" {
+		t.Errorf("Expected web project rooted at apps/web/src, got %s at %s", web.Name, web.SourceRoot)
+	}
+	if len(web.ImplicitDependencies) != 1 || web.ImplicitDependencies[0] != "api" {
+		t.Errorf("Expected web to depend on api, got %v", web.ImplicitDependencies)
+	}
+	build, ok := web.Targets["build"]
+	if !ok {
+		t.Fatal("Expected web to have a build target")
+	}
+	if build.Executor != "nx:run-script" || build.Options["script"] != "build" {
+		t.Errorf("Expected build target to run the build script, got %s %v", build.Executor, build.Options)
+	}
+	if len(build.Outputs) != 1 || build.Outputs[0] != "{projectRoot}/.next" {
+		t.Errorf("Expected Next.js build output, got %v", build.Outputs)
+	}
+	if !web.Targets["dev"].Continuous {
+		t.Error("Expected dev target to be continuous")
+	}
+
+	api := buildNxProject(project, project.Applications[1])
+	if len(api.ImplicitDependencies) != 0 {
+		t.Errorf("Expected server apps to have no implicit dependencies, got %v", api.ImplicitDependencies)
+	}
+}
+
+func TestBuildPlan_Nx(t *testing.T) {
+	plan, err := BuildPlan(nxTestProject())
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	paths := planPaths(plan)
+	for _, path := range []string{"nx.json", "apps/web/project.json", "apps/api/project.json"} {
+		if !paths[path] {
+			t.Errorf("Expected plan to contain %s", path)
+		}
+	}
+	if paths["turbo.json"] {
+		t.Error("Expected no turbo.json for an Nx project")
+	}
+
+	var root packageJSON
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			if file.Path == "package.json" {
+				if err := json.Unmarshal(file.Content, &root); err != nil {
+					t.Fatalf("Expected valid root package.json, got error: %v", err)
+				}
+			}
+		}
+	}
+	if root.Scripts["build"] != "nx run-many -t build" {
+		t.Errorf("Expected build script to run nx, got %q", root.Scripts["build"])
+	}
+	if root.DevDependencies["nx"] == "" {
+		t.Error("Expected nx as a root dev dependency")
+	}
+}
@@ -0,0 +1,150 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"teapot/internal/models"
+)
+
+// packageManager describes how generated files install dependencies and run
+// scripts with one package manager.
+type packageManager struct {
+	// spec is the packageManager field written to package.json, pinned for Corepack
+	spec string
+	// lockfile is the lockfile the first install writes
+	lockfile string
+	// install installs the dependencies from the committed lockfile, failing if it is outdated
+	install string
+	// exec runs a binary installed in node_modules
+	exec string
+	// image is the container image CI and the Dockerfiles build in
+	image string
+	// corepack reports whether the package manager is enabled through Corepack
+	corepack bool
+	// workspaceProtocol is the version used for dependencies on workspace packages
+	workspaceProtocol string
+}
+
+// packageManagers lists the supported package managers
+var packageManagers = map[models.PackageManagerType]packageManager{
+	models.PackageManagerBun: {
+		spec:              "bun@1.1.30",
+		lockfile:          "bun.lockb",
+		install:           "bun install --frozen-lockfile",
+		exec:              "bunx",
+		image:             "oven/bun:1",
+		workspaceProtocol: "workspace:*",
+	},
+	models.PackageManagerPnpm: {
+		spec:              "pnpm@9.12.0",
+		lockfile:          "pnpm-lock.yaml",
+		install:           "pnpm install --frozen-lockfile",
+		exec:              "pnpm exec",
+		image:             "node:20-slim",
+		corepack:          true,
+		workspaceProtocol: "workspace:*",
+	},
+	models.PackageManagerNpm: {
+		spec:     "npm@10.9.0",
+		lockfile: "package-lock.json",
+		install:  "npm ci",
+		exec:     "npx",
+		image:    "node:20-slim",
+		// npm links workspace packages by name and does not know the workspace: protocol
+		workspaceProtocol: "*",
+	},
+	models.PackageManagerYarn: {
+		spec:              "yarn@4.5.0",
+		lockfile:          "yarn.lock",
+		install:           "yarn install --immutable",
+		exec:              "yarn",
+		image:             "node:20-slim",
+		corepack:          true,
+		workspaceProtocol: "workspace:*",
+	},
+}
+
+// ProjectPackageManager returns the package manager of a project, Bun when none is configured.
+func ProjectPackageManager(project models.ProjectConfig) models.PackageManagerType {
+	if project.PackageManager == "" {
+		return models.PackageManagerBun
+	}
+	return project.PackageManager
+}
+
+// projectPackageManager returns how the files of a project use its package manager.
+func projectPackageManager(project models.ProjectConfig) packageManager {
+	return packageManagers[ProjectPackageManager(project)]
+}
+
+// InstallCommand returns the command that installs the dependencies of a new project.
+func InstallCommand(pm models.PackageManagerType) string {
+	return string(pm) + " install"
+}
+
+// RunCommand returns the command that runs a package.json script.
+func RunCommand(pm models.PackageManagerType, script string) string {
+	return string(pm) + " run " + script
+}
+
+// workspaceScript returns the root script that runs a task in every workspace package.
+func workspaceScript(pm models.PackageManagerType, task string) string {
+	switch pm {
+	case models.PackageManagerPnpm:
+		return "pnpm -r run " + task
+	case models.PackageManagerNpm:
+		return "npm run " + task + " --workspaces --if-present"
+	case models.PackageManagerYarn:
+		return "yarn workspaces foreach --all run " + task
+	default:
+		return "bun run --filter '*' " + task
+	}
+}
+
+// appBuildCommand returns the command that builds the app selected by the APP
+// build argument of the workspace Dockerfile.
+func appBuildCommand(project models.ProjectConfig) string {
+	switch ProjectPackageManager(project) {
+	case models.PackageManagerPnpm:
+		return `pnpm --filter "./apps/${APP}" run build`
+	case models.PackageManagerNpm:
+		return `npm run build --workspace "apps/${APP}"`
+	case models.PackageManagerYarn:
+		// Yarn selects workspaces by package name
+		return fmt.Sprintf(`yarn workspace "@%s/${APP}" run build`, strings.ToLower(project.Name))
+	default:
+		return `bun run --filter "./apps/${APP}" build`
+	}
+}
+
+// execForm returns a command in the JSON array form of a Dockerfile CMD.
+func execForm(command string) string {
+	return `["` + strings.Join(strings.Fields(command), `", "`) + `"]`
+}
+
+// packageManagerFiles returns the configuration files a package manager needs
+// next to the root package.json.
+func packageManagerFiles(project models.ProjectConfig) []File {
+	var files []File
+	switch ProjectPackageManager(project) {
+	case models.PackageManagerPnpm:
+		// pnpm reads its workspaces from pnpm-workspace.yaml instead of package.json
+		if !IsSingleApp(project) {
+			files = append(files, textFile("pnpm-workspace.yaml", pnpmWorkspaceContent))
+		}
+	case models.PackageManagerYarn:
+		files = append(files, textFile(".yarnrc.yml", yarnrcContent))
+	}
+	return files
+}
+
+const pnpmWorkspaceContent = `packages:
+  - "apps/*"
+  - "packages/*"
+`
+
+// yarnrcContent installs into node_modules, which the generated tooling expects,
+// instead of Plug'n'Play
+const yarnrcContent = `nodeLinker: node-modules
+`
@@ -0,0 +1,159 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+func TestBuildPlan_PackageManagers(t *testing.T) {
+	tests := []struct {
+		pm        models.PackageManagerType
+		spec      string
+		install   string
+		hook      string
+		dockerRun string
+		files     []string
+		absent    []string
+		protocol  string
+	}{
+		{
+			pm:        "",
+			spec:      "bun@",
+			install:   "bun install --frozen-lockfile",
+			hook:      "bunx lint-staged",
+			dockerRun: `RUN bun run --filter "./apps/${APP}" build`,
+			absent:    []string{"pnpm-workspace.yaml", ".yarnrc.yml"},
+			protocol:  "workspace:*",
+		},
+		{
+			pm:        models.PackageManagerPnpm,
+			spec:      "pnpm@",
+			install:   "pnpm install --frozen-lockfile",
+			hook:      "pnpm exec lint-staged",
+			dockerRun: `RUN pnpm --filter "./apps/${APP}" run build`,
+			files:     []string{"pnpm-workspace.yaml"},
+			absent:    []string{".yarnrc.yml"},
+			protocol:  "workspace:*",
+		},
+		{
+			pm:        models.PackageManagerNpm,
+			spec:      "npm@",
+			install:   "npm ci",
+			hook:      "npx lint-staged",
+			dockerRun: `RUN npm run build --workspace "apps/${APP}"`,
+			absent:    []string{"pnpm-workspace.yaml", ".yarnrc.yml"},
+			protocol:  "*",
+		},
+		{
+			pm:        models.PackageManagerYarn,
+			spec:      "yarn@",
+			install:   "yarn install --immutable",
+			hook:      "yarn lint-staged",
+			dockerRun: `RUN yarn workspace "@test-project/${APP}" run build`,
+			files:     []string{".yarnrc.yml"},
+			absent:    []string{"pnpm-workspace.yaml"},
+			protocol:  "workspace:*",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(ProjectPackageManager(models.ProjectConfig{PackageManager: tt.pm})), func(t *testing.T) {
+			project := packagesTestProject()
+			project.PackageManager = tt.pm
+			plan, err := BuildPlan(project)
+			if err != nil {
+				t.Fatalf("Expected plan to build, got error: %v", err)
+			}
+			files := planFiles(plan)
+
+			var root packageJSON
+			if err := json.Unmarshal(files["package.json"].Content, &root); err != nil {
+				t.Fatalf("Expected valid package.json, got error: %v", err)
+			}
+			if !strings.HasPrefix(root.PackageManager, tt.spec) {
+				t.Errorf("Expected packageManager %s*, got %q", tt.spec, root.PackageManager)
+			}
+			if pnpm := tt.pm == models.PackageManagerPnpm; pnpm != (root.Workspaces == nil) {
+				t.Errorf("Expected package.json workspaces only without pnpm, got %v", root.Workspaces)
+			}
+
+			var web packageJSON
+			if err := json.Unmarshal(files["apps/web/package.json"].Content, &web); err != nil {
+				t.Fatalf("Expected valid package.json, got error: %v", err)
+			}
+			if got := web.Dependencies["@test-project/ui"]; got != tt.protocol {
+				t.Errorf("Expected workspace dependencies on %q, got %q", tt.protocol, got)
+			}
+
+			for path, want := range map[string]string{
+				".github/workflows/ci.yml": "run: " + tt.install,
+				"Dockerfile":               tt.dockerRun,
+				"README.md":                tt.install,
+				".husky/pre-commit":        tt.hook,
+			} {
+				if !strings.Contains(string(files[path].Content), want) {
+					t.Errorf("Expected %s to contain %q, got:\n%s", path, want, files[path].Content)
+				}
+			}
+			for _, path := range tt.files {
+				if _, ok := files[path]; !ok {
+					t.Errorf("Expected %s to be generated", path)
+				}
+			}
+			for _, path := range tt.absent {
+				if _, ok := files[path]; ok {
+					t.Errorf("Expected no %s", path)
+				}
+			}
+		})
+	}
+}
+
+func TestRenderDockerfile_SingleApp(t *testing.T) {
+	project := testProject()
+	project.Architecture = models.ArchitectureSingle
+	project.Applications = project.Applications[:1]
+	project.PackageManager = models.PackageManagerPnpm
+
+	want := "FROM node:20-slim AS base\nRUN corepack enable\nWORKDIR /app\n\nCOPY . .\n" +
+		"RUN pnpm install --frozen-lockfile\nRUN pnpm run build\n\nCMD [\"pnpm\", \"run\", \"start\"]\n"
+	if got := renderDockerfile(project); got != want {
+		t.Errorf("Expected Dockerfile:\n%s\ngot:\n%s", want, got)
+	}
+}
+
+func TestRenderDockerfile_StartScript(t *testing.T) {
+	for appType := range models.AppTypeNames {
+		// Mobile apps are not containerised
+		if appType == models.AppTypeExpo {
+			continue
+		}
+		for _, typescript := range []bool{true, false} {
+			project := testProject()
+			project.Architecture = models.ArchitectureSingle
+			project.Applications = []models.Application{
+				{ID: "app", Name: "app", Type: appType, Options: map[string]interface{}{"typescript": typescript}},
+			}
+			plan, err := BuildPlan(project)
+			if err != nil {
+				t.Fatalf("Expected a %s plan to build, got error: %v", appType, err)
+			}
+
+			content := string(planFiles(plan)["package.json"].Content)
+			if !strings.Contains(renderDockerfile(project), `"run", "start"]`) || !strings.Contains(content, `"start":`) {
+				t.Errorf("Expected %s to have the start script the Dockerfile runs, got:\n%s", appType, content)
+			}
+		}
+	}
+}
+
+func TestBuildPlan_UnknownPackageManager(t *testing.T) {
+	project := testProject()
+	project.PackageManager = "pip"
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected an unknown package manager to be rejected")
+	}
+}
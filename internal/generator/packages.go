@@ -0,0 +1,156 @@
+package generator
+
+import (
+	"path"
+	"strings"
+
+	"teapot/internal/models"
+)
+
+// PackageDir returns the folder of a shared package relative to the project root.
+func PackageDir(pkg models.PackageType) string {
+	return "packages/" + string(pkg)
+}
+
+// SharedPackageName returns the workspace package name for a shared package.
+func SharedPackageName(project models.ProjectConfig, pkg models.PackageType) string {
+	return "@" + strings.ToLower(project.Name) + "/" + string(pkg)
+}
+
+// projectPackages returns the shared packages generated for a project.
+// Single-application projects have no workspace and therefore no packages.
+func projectPackages(project models.ProjectConfig) []models.PackageType {
+	if IsSingleApp(project) {
+		return nil
+	}
+	return project.Packages
+}
+
+// hasPackage reports whether a shared package is generated for a project.
+func hasPackage(project models.ProjectConfig, pkg models.PackageType) bool {
+	for _, p := range projectPackages(project) {
+		if p == pkg {
+			return true
+		}
+	}
+	return false
+}
+
+// isConfigPackage reports whether a shared package only holds tooling configuration
+// and is therefore a dev dependency of the apps that use it.
+func isConfigPackage(pkg models.PackageType) bool {
+	switch pkg {
+	case models.PackageTSConfig, models.PackageESLintConfig, models.PackagePrettierConfig:
+		return true
+	default:
+		return false
+	}
+}
+
+// packageUsedBy reports whether an application type depends on a shared package.
+// Config, auth and utils packages are used by every app; the UI library and API
+// client by the web frontends; database models by the apps that run server code.
+func packageUsedBy(pkg models.PackageType, appType models.AppType) bool {
+	switch pkg {
+	case models.PackageUI:
+		return appType == models.AppTypeReact || appType == models.AppTypeNext || appType == models.AppTypeTanStack
+	case models.PackageAPIClient:
+		return !IsServerApp(appType)
+	case models.PackageDatabase:
+		return IsServerApp(appType) || appType == models.AppTypeNext || appType == models.AppTypeTanStack
+	default:
+		return true
+	}
+}
+
+// appPackages returns the shared packages an application depends on.
+func appPackages(project models.ProjectConfig, app models.Application) []models.PackageType {
+	var packages []models.PackageType
+	for _, pkg := range projectPackages(project) {
+		if packageUsedBy(pkg, app.Type) {
+			packages = append(packages, pkg)
+		}
+	}
+	return packages
+}
+
+// addWorkspaceDependencies wires the shared packages an application uses into its manifest.
+func addWorkspaceDependencies(project models.ProjectConfig, app models.Application, manifest *packageJSON) {
+	for _, pkg := range appPackages(project, app) {
+		deps := &manifest.Dependencies
+		if isConfigPackage(pkg) {
+			deps = &manifest.DevDependencies
+		}
+		if *deps == nil {
+			*deps = make(map[string]string)
+		}
+		(*deps)[SharedPackageName(project, pkg)] = projectPackageManager(project).workspaceProtocol
+	}
+}
+
+// packageManifest returns the package.json of a shared package.
+func packageManifest(project models.ProjectConfig, pkg models.PackageType) packageJSON {
+	manifest := packageJSON{
+		Name:        SharedPackageName(project, pkg),
+		Version:     "0.0.0",
+		Private:     true,
+		Description: models.PackageTypeNames[pkg],
+		Type:        "module",
+	}
+
+	switch pkg {
+	case models.PackageTSConfig:
+		manifest.Type = ""
+		manifest.Files = []string{"base.json", "react.json", "node.json"}
+	case models.PackageESLintConfig:
+		manifest.Exports = map[string]string{".": "./index.js"}
+		manifest.Dependencies = map[string]string{"typescript-eslint": "^8.8.0"}
+		manifest.PeerDependencies = map[string]string{"eslint": "^9.0.0"}
+	case models.PackagePrettierConfig:
+		manifest.Type = ""
+		manifest.Exports = map[string]string{".": "./index.json"}
+	default:
+		manifest.Exports = map[string]string{".": "./src/index.ts"}
+		manifest.Scripts = map[string]string{"lint": lintCommand(project.DevTools.Linting)}
+		manifest.DevDependencies = map[string]string{"typescript": "^5.6.0"}
+	}
+
+	switch pkg {
+	case models.PackageUI:
+		manifest.Exports["./*"] = "./src/*.tsx"
+		manifest.PeerDependencies = map[string]string{"react": "^18.3.0"}
+		manifest.DevDependencies["@types/react"] = "^18.3.0"
+	case models.PackageDatabase:
+		manifest.Scripts["db:generate"] = "prisma generate"
+		manifest.Scripts["db:migrate"] = "prisma migrate dev"
+		manifest.Scripts["postinstall"] = "prisma generate"
+		manifest.Dependencies = map[string]string{"@prisma/client": "^5.20.0"}
+		manifest.DevDependencies["prisma"] = "^5.20.0"
+		manifest.DevDependencies["@types/node"] = "^22.0.0"
+	case models.PackageAuth:
+		manifest.Dependencies = map[string]string{"jose": "^5.9.0"}
+	}
+	return manifest
+}
+
+// packagesStep emits the shared workspace packages selected for the project.
+func packagesStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Shared packages created", Description: "Generating shared packages"}
+
+	for _, pkg := range projectPackages(project) {
+		dir := PackageDir(pkg)
+
+		packageFile, err := jsonFile(path.Join(dir, "package.json"), packageManifest(project, pkg))
+		if err != nil {
+			return step, err
+		}
+		step.Files = append(step.Files, packageFile)
+
+		files, err := renderPackageTemplates(newPackageTemplateData(project, pkg), dir)
+		if err != nil {
+			return step, err
+		}
+		step.Files = append(step.Files, files...)
+	}
+	return step, nil
+}
@@ -0,0 +1,152 @@
+package generator
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+// packagesTestProject returns the test project with shared packages selected
+func packagesTestProject() models.ProjectConfig {
+	project := testProject()
+	project.Packages = []models.PackageType{
+		models.PackageUI,
+		models.PackageTSConfig,
+		models.PackageESLintConfig,
+		models.PackagePrettierConfig,
+		models.PackageDatabase,
+		models.PackageAuth,
+		models.PackageUtils,
+		models.PackageAPIClient,
+	}
+	return project
+}
+
+// planFiles collects every planned file keyed by path
+func planFiles(plan *Plan) map[string]File {
+	files := make(map[string]File)
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			files[file.Path] = file
+		}
+	}
+	return files
+}
+
+func TestBuildPlan_Packages(t *testing.T) {
+	plan, err := BuildPlan(packagesTestProject())
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+
+	files := planFiles(plan)
+	for _, pkg := range packagesTestProject().Packages {
+		path := PackageDir(pkg) + "/package.json"
+		if _, ok := files[path]; !ok {
+			t.Errorf("Expected plan to contain %s", path)
+		}
+	}
+	for _, path := range []string{
+		"packages/ui/src/button.tsx",
+		"packages/tsconfig/base.json",
+		"packages/database/prisma/schema.prisma",
+		"packages/api-client/src/index.ts",
+	} {
+		if _, ok := files[path]; !ok {
+			t.Errorf("Expected plan to contain %s", path)
+		}
+	}
+
+	if !strings.Contains(string(files["apps/web/tsconfig.json"].Content), "@test-project/tsconfig/base.json") {
+		t.Error("Expected apps to extend the shared tsconfig package")
+	}
+	if !strings.Contains(string(files["apps/web/next.config.js"].Content), `"@test-project/ui"`) {
+		t.Error("Expected Next.js to transpile the shared UI package")
+	}
+	if !strings.Contains(string(files["eslint.config.mjs"].Content), "@test-project/eslint-config") {
+		t.Error("Expected root ESLint config to use the shared package")
+	}
+}
+
+func TestBuildPlan_PackageDependencies(t *testing.T) {
+	plan, err := BuildPlan(packagesTestProject())
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+	files := planFiles(plan)
+
+	tests := []struct {
+		path       string
+		deps       []string
+		devDeps    []string
+		absentDeps []string
+	}{
+		{
+			path:       "apps/web/package.json",
+			deps:       []string{"@test-project/ui", "@test-project/api-client", "@test-project/utils"},
+			devDeps:    []string{"@test-project/tsconfig", "@test-project/eslint-config"},
+			absentDeps: []string{},
+		},
+		{
+			path:       "apps/api/package.json",
+			deps:       []string{"@test-project/database", "@test-project/auth"},
+			devDeps:    []string{"@test-project/tsconfig"},
+			absentDeps: []string{"@test-project/ui", "@test-project/api-client"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			var manifest packageJSON
+			if err := json.Unmarshal(files[tt.path].Content, &manifest); err != nil {
+				t.Fatalf("Expected valid package.json, got error: %v", err)
+			}
+			for _, dep := range tt.deps {
+				if manifest.Dependencies[dep] != "workspace:*" {
+					t.Errorf("Expected dependency %s on the workspace, got %q", dep, manifest.Dependencies[dep])
+				}
+			}
+			for _, dep := range tt.devDeps {
+				if manifest.DevDependencies[dep] != "workspace:*" {
+					t.Errorf("Expected dev dependency %s on the workspace, got %q", dep, manifest.DevDependencies[dep])
+				}
+			}
+			for _, dep := range tt.absentDeps {
+				if _, ok := manifest.Dependencies[dep]; ok {
+					t.Errorf("Expected no dependency on %s", dep)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildPlan_PackagesIgnoredForSingleApp(t *testing.T) {
+	project := packagesTestProject()
+	project.Architecture = models.ArchitectureSingle
+	project.Applications = project.Applications[:1]
+
+	plan, err := BuildPlan(project)
+	if err != nil {
+		t.Fatalf("Expected plan to build, got error: %v", err)
+	}
+	for path := range planFiles(plan) {
+		if strings.HasPrefix(path, "packages/") {
+			t.Errorf("Expected no shared packages for a single application, got %s", path)
+		}
+	}
+}
+
+func TestBuildPlan_InvalidPackages(t *testing.T) {
+	project := testProject()
+	project.Packages = []models.PackageType{"unknown"}
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected unknown package to fail")
+	}
+
+	project.Packages = []models.PackageType{models.PackageUI, models.PackageUI}
+	if _, err := BuildPlan(project); err == nil {
+		t.Error("Expected duplicate package to fail")
+	}
+}
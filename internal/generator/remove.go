@@ -0,0 +1,171 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"teapot/internal/models"
+	"teapot/internal/validation"
+)
+
+// Removal is the plan for removing an application from a generated project.
+type Removal struct {
+	// Upgrade rewrites only the files that reference the application, which
+	// drops its workspace, pipeline, compose, CI and dependency references
+	*Upgrade
+	// App is the application being removed
+	App models.Application
+	// Dir is the slash-separated folder of the application
+	Dir string
+	// Deleted lists the files deleted with the folder; folders teapot did not
+	// generate anything in are listed once with a trailing slash
+	Deleted []string
+}
+
+// FindApplication returns the index of the application with the given ID or
+// name. IDs are tried first; an ID shared by several applications is ambiguous.
+func FindApplication(project models.ProjectConfig, ref string) (int, error) {
+	var byID, byName []int
+	for i, app := range project.Applications {
+		if app.ID == ref {
+			byID = append(byID, i)
+		}
+		if app.Name == ref {
+			byName = append(byName, i)
+		}
+	}
+
+	switch {
+	case len(byID) == 1:
+		return byID[0], nil
+	case len(byName) == 1:
+		return byName[0], nil
+	case len(byID) > 1:
+		return -1, fmt.Errorf("%d applications have the ID %q, use the application name instead", len(byID), ref)
+	default:
+		return -1, fmt.Errorf("%s has no application %q", project.Name, ref)
+	}
+}
+
+// PlanRemoval plans removing the application at index from the project in root.
+// Nothing is written or deleted until Apply is called.
+func PlanRemoval(root string, project models.ProjectConfig, index int) (*Removal, error) {
+	if IsSingleApp(project) {
+		return nil, fmt.Errorf("%s is a single application project and its application cannot be removed", project.Name)
+	}
+
+	app := project.Applications[index]
+	if app.Name != "" {
+		if err := validation.ValidateAppName(app.Name); err != nil {
+			return nil, fmt.Errorf("application %q cannot be removed: %w", app.Name, err)
+		}
+	}
+	dir := AppDir(project, app)
+	if _, err := appFolder(root, dir); err != nil {
+		return nil, err
+	}
+
+	remaining := project
+	remaining.Applications = append(append([]models.Application{}, project.Applications[:index]...), project.Applications[index+1:]...)
+
+	upgrade, err := planChange(root, project, remaining)
+	if err != nil {
+		return nil, err
+	}
+
+	removal := &Removal{Upgrade: upgrade, App: app, Dir: dir}
+	base, err := LoadBase(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		// Without recorded content every folder is listed as a whole
+		base = &Base{Files: make(map[string]string)}
+	} else if err != nil {
+		return nil, err
+	}
+	if removal.Deleted, err = listDeleted(root, removal.Dir, base); err != nil {
+		return nil, err
+	}
+
+	// The application's own files are deleted, not left behind as orphans
+	var orphaned []string
+	for _, path := range upgrade.Orphaned {
+		if !strings.HasPrefix(path, removal.Dir+"/") {
+			orphaned = append(orphaned, path)
+		}
+	}
+	upgrade.Orphaned = orphaned
+	return removal, nil
+}
+
+// listDeleted lists the files in dir. Folders without generated files, such as
+// node_modules, are listed as a whole instead of file by file.
+func listDeleted(root, dir string, base *Base) ([]string, error) {
+	var deleted []string
+	err := filepath.WalkDir(filepath.Join(root, filepath.FromSlash(dir)), func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(root, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		if !entry.IsDir() {
+			deleted = append(deleted, rel)
+			return nil
+		}
+		if rel != dir && !hasGenerated(base, rel) {
+			deleted = append(deleted, rel+"/")
+			return filepath.SkipDir
+		}
+		return nil
+	})
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list %s: %w", dir, err)
+	}
+	sort.Strings(deleted)
+	return deleted, nil
+}
+
+// hasGenerated reports whether teapot generated any file below dir.
+func hasGenerated(base *Base, dir string) bool {
+	for path := range base.Files {
+		if strings.HasPrefix(path, dir+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// appFolder returns the path of an application folder below root, refusing
+// anything that is not a folder directly inside root/apps.
+func appFolder(root, dir string) (string, error) {
+	path := filepath.Join(root, filepath.FromSlash(dir))
+	if filepath.Dir(path) != filepath.Join(root, "apps") {
+		return "", fmt.Errorf("%s is not an application folder in %s", dir, filepath.Join(root, "apps"))
+	}
+	return path, nil
+}
+
+// Apply rewrites the files that reference the application and deletes its folder.
+func (r *Removal) Apply() error {
+	folder, err := appFolder(r.root, r.Dir)
+	if err != nil {
+		return err
+	}
+	if err := r.Upgrade.Apply(); err != nil {
+		return err
+	}
+	if err := os.RemoveAll(folder); err != nil {
+		return fmt.Errorf("failed to delete %s: %w", r.Dir, err)
+	}
+	return nil
+}
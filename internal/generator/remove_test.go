@@ -0,0 +1,168 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+func TestFindApplication(t *testing.T) {
+	project := testProject()
+	project.Applications = append(project.Applications, project.Applications[0])
+	project.Applications[2].Name = "admin"
+
+	tests := []struct {
+		ref      string
+		expected int
+		wantErr  bool
+	}{
+		{"app-nest", 1, false},
+		{"admin", 2, false},
+		{"web", 0, false},
+		{"app-next", -1, true},
+		{"missing", -1, true},
+	}
+
+	for _, tt := range tests {
+		index, err := FindApplication(project, tt.ref)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("FindApplication(%q): expected error %v, got %v", tt.ref, tt.wantErr, err)
+		}
+		if index != tt.expected {
+			t.Errorf("FindApplication(%q): expected %d, got %d", tt.ref, tt.expected, index)
+		}
+	}
+}
+
+func TestPlanRemoval(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "demo")
+	if err := NewEngine(testProject(), root).Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+	if err := os.MkdirAll(filepath.Join(root, "apps", "api", "node_modules", "dep"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	writeProjectFile(t, root, "apps/api/node_modules/dep/index.js", "")
+	writeProjectFile(t, root, "README.md", readProjectFile(t, root, "README.md")+"local notes\n")
+
+	references := map[string]string{
+		"README.md":          "apps/api",
+		"turbo.json":         "@test-project/api#",
+		"docker-compose.yml": "APP: api",
+		"teapot.yml":         "name: api",
+	}
+	for path, reference := range references {
+		if !strings.Contains(readProjectFile(t, root, path), reference) {
+			t.Fatalf("Expected the generated %s to contain %q", path, reference)
+		}
+	}
+
+	removal, err := PlanRemoval(root, testProject(), 1)
+	if err != nil {
+		t.Fatalf("Expected removal to plan, got error: %v", err)
+	}
+	if removal.Dir != "apps/api" {
+		t.Errorf("Expected apps/api to be removed, got %s", removal.Dir)
+	}
+
+	deleted := strings.Join(removal.Deleted, "\n")
+	if !strings.Contains(deleted, "apps/api/package.json") || !strings.Contains(deleted, "apps/api/node_modules/\n") {
+		t.Errorf("Expected generated files and untracked folders to be listed, got:\n%s", deleted)
+	}
+	if strings.Contains(deleted, "index.js") {
+		t.Errorf("Expected untracked folders to be listed as a whole, got:\n%s", deleted)
+	}
+	if len(removal.Orphaned) != 0 {
+		t.Errorf("Expected the application's files not to be reported as orphans, got %v", removal.Orphaned)
+	}
+	for _, file := range removal.Files {
+		if strings.HasPrefix(file.Path, "apps/web/") || file.Path == "package.json" || file.Path == "Dockerfile" {
+			t.Errorf("Expected %s not to be touched by removing api", file.Path)
+		}
+	}
+
+	if err := removal.Apply(); err != nil {
+		t.Fatalf("Expected removal to apply, got error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "apps", "api")); !os.IsNotExist(err) {
+		t.Error("Expected the application folder to be deleted")
+	}
+	for path, reference := range references {
+		if strings.Contains(readProjectFile(t, root, path), reference) {
+			t.Errorf("Expected %s to no longer contain %q", path, reference)
+		}
+	}
+	if !strings.Contains(readProjectFile(t, root, "README.md"), "local notes") {
+		t.Error("Expected local edits to be kept")
+	}
+	base, err := LoadBase(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if hasGenerated(base, "apps/api") {
+		t.Error("Expected the application's files to be dropped from the generated content")
+	}
+}
+
+func TestPlanRemoval_WithoutBase(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "demo")
+	if err := NewEngine(testProject(), root).Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+	if err := os.Remove(filepath.Join(root, filepath.FromSlash(BasePath))); err != nil {
+		t.Fatal(err)
+	}
+	writeProjectFile(t, root, "turbo.json", readProjectFile(t, root, "turbo.json")+"\n")
+	turbo := readProjectFile(t, root, "turbo.json")
+
+	removal, err := PlanRemoval(root, testProject(), 1)
+	if err != nil {
+		t.Fatalf("Expected removal to plan, got error: %v", err)
+	}
+	if err := removal.Apply(); err != nil {
+		t.Fatalf("Expected removal to apply, got error: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(root, "apps", "api")); !os.IsNotExist(err) {
+		t.Error("Expected the application folder to be deleted")
+	}
+	if strings.Contains(readProjectFile(t, root, "turbo.json"), "@test-project/api#") {
+		t.Error("Expected turbo.json to no longer reference the application")
+	}
+	if backup := readProjectFile(t, root, removal.Backup+"/turbo.json"); backup != turbo {
+		t.Errorf("Expected the previous turbo.json to be backed up, got:\n%s", backup)
+	}
+}
+
+func TestPlanRemoval_SingleApp(t *testing.T) {
+	project := testProject()
+	project.Architecture = models.ArchitectureSingle
+	project.Applications = project.Applications[:1]
+	if _, err := PlanRemoval(t.TempDir(), project, 0); err == nil {
+		t.Error("Expected removing the application of a single application project to fail")
+	}
+}
+
+func TestPlanRemoval_TraversalName(t *testing.T) {
+	parent := t.TempDir()
+	root := filepath.Join(parent, "demo")
+	if err := NewEngine(testProject(), root).Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+
+	project := testProject()
+	project.Applications[1].Name = "../.."
+	if _, err := PlanRemoval(root, project, 1); err == nil {
+		t.Error("Expected an application name leaving apps/ to be refused")
+	}
+
+	removal := &Removal{Upgrade: &Upgrade{root: root}, Dir: "apps/../.."}
+	if err := removal.Apply(); err == nil {
+		t.Error("Expected deleting a folder outside apps/ to be refused")
+	}
+	if _, err := os.Stat(filepath.Join(root, "package.json")); err != nil {
+		t.Errorf("Expected the project to be left alone, got %v", err)
+	}
+}
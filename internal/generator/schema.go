@@ -0,0 +1,203 @@
+package generator
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"teapot/internal/validation"
+)
+
+// jsonSchemaDraft is the JSON Schema dialect of the exported schema; it is the
+// newest draft yaml-language-server fully supports
+const jsonSchemaDraft = "http://json-schema.org/draft-07/schema#"
+
+// SchemaChoices lists the values offered for the settings teapot.yml stores as
+// plain strings. Most of them are defined by the wizard screens, so the caller
+// supplies them. Both the JSON Schema and ValidateTeapotYAML check against them.
+type SchemaChoices struct {
+	// Architectures lists the architecture keys
+	Architectures []string
+	// PackageManagers lists the package manager keys
+	PackageManagers []string
+	// AppTypes lists the application type keys
+	AppTypes []string
+	// Packages lists the shared package keys
+	Packages []string
+	// LintingTools lists the linting setup keys
+	LintingTools []string
+	// CIProviders lists the CI/CD provider keys
+	CIProviders []string
+	// CIFeatures lists the pipeline feature keys
+	CIFeatures []string
+	// AITools lists the AI tool keys that can be combined in aiTools.editor
+	AITools []string
+	// AppOptions lists the options of every application type
+	AppOptions map[string][]SchemaOption
+}
+
+// SchemaOption describes one option key of an application type.
+type SchemaOption struct {
+	// Key is the key used in the application's options
+	Key string
+	// Description is shown by editors while completing the key
+	Description string
+}
+
+// schemaDescriptions documents every teapot.yml field, keyed by its dotted
+// path; list items share the path of their list.
+var schemaDescriptions = map[string]string{
+	"version":                      "teapot.yml format version. Run 'teapot migrate-config' to update files written in an older version.",
+	"project":                      "The project being generated.",
+	"project.name":                 "Project name, also used as the output directory and workspace scope.",
+	"project.description":          "Short description written to the generated README and package.json.",
+	"project.architecture":         "How the repository is organized.",
+	"project.packageManager":       "Package manager used by scripts, CI and Dockerfiles. Defaults to bun.",
+	"applications":                 "Applications generated in the project; single application projects have exactly one.",
+	"applications.id":              "Identifier of the application.",
+	"applications.name":            "Folder name of the application under apps/.",
+	"applications.type":            "Framework the application is generated with.",
+	"applications.options":         "Features enabled for the application; the available keys depend on its type.",
+	"packages":                     "Shared workspace packages generated under packages/.",
+	"devTools":                     "Development tooling.",
+	"devTools.linting":             "Linting and formatting setup.",
+	"devTools.typescript":          "Generate TypeScript configuration.",
+	"devTools.husky":               "Install Husky git hooks.",
+	"devTools.lintStaged":          "Run linters on staged files before every commit.",
+	"infrastructure":               "Infrastructure files.",
+	"infrastructure.docker":        "Generate a root Dockerfile; in monorepos it builds the application named by the APP build argument.",
+	"infrastructure.dockerCompose": "Generate a docker-compose.yml with a service per application.",
+	"infrastructure.pulumi":        "Generate a Pulumi program.",
+	"infrastructure.terraform":     "Generate Terraform configuration.",
+	"ciPipeline":                   "CI/CD pipeline.",
+	"ciPipeline.provider":          "CI/CD provider, or skip for none.",
+	"ciPipeline.features":          "Jobs the pipeline runs.",
+	"aiTools":                      "AI coding tools.",
+	"aiTools.editor":               "Comma-separated AI tools to configure, or none.",
+	"aiTools.extensions":           "Extensions recommended for the chosen AI tools.",
+}
+
+// schemaRequired lists the fields that must be present in each object, keyed by
+// the object's dotted path.
+var schemaRequired = map[string][]string{
+	"":             {"version", "project"},
+	"project":      {"name", "architecture"},
+	"applications": {"type"},
+}
+
+// TeapotYAMLSchema returns a JSON Schema describing teapot.yml. The structure
+// is derived from TeapotConfig, so the schema stays in sync with what
+// LoadTeapotYAML accepts.
+func TeapotYAMLSchema(choices SchemaChoices) ([]byte, error) {
+	enums := map[string][]string{
+		"version":                {teapotYAMLVersion},
+		"project.architecture":   choices.Architectures,
+		"project.packageManager": choices.PackageManagers,
+		"applications.type":      choices.AppTypes,
+		"packages":               choices.Packages,
+		"devTools.linting":       choices.LintingTools,
+		"ciPipeline.provider":    choices.CIProviders,
+		"ciPipeline.features":    choices.CIFeatures,
+	}
+
+	schema := schemaFor(reflect.TypeOf(TeapotConfig{}), "", enums)
+	schema["$schema"] = jsonSchemaDraft
+	schema["title"] = "teapot.yml"
+
+	properties := schema["properties"].(map[string]interface{})
+	if len(choices.AITools) > 0 {
+		editor := properties["aiTools"].(map[string]interface{})["properties"].(map[string]interface{})["editor"].(map[string]interface{})
+		editor["pattern"] = listPattern(choices.AITools)
+	}
+
+	// Application names become folders, so they follow the rule BuildPlan enforces
+	items := properties["applications"].(map[string]interface{})["items"].(map[string]interface{})
+	items["properties"].(map[string]interface{})["name"].(map[string]interface{})["pattern"] = validation.AppNamePattern
+
+	// Option keys depend on the application type
+	var byType []interface{}
+	for _, appType := range choices.AppTypes {
+		options := make(map[string]interface{})
+		for _, option := range choices.AppOptions[appType] {
+			options[option.Key] = map[string]interface{}{"type": "boolean", "description": option.Description}
+		}
+		byType = append(byType, map[string]interface{}{
+			"if": map[string]interface{}{
+				"properties": map[string]interface{}{"type": map[string]interface{}{"const": appType}},
+				"required":   []string{"type"},
+			},
+			"then": map[string]interface{}{
+				"properties": map[string]interface{}{
+					"options": map[string]interface{}{"properties": options, "additionalProperties": false},
+				},
+			},
+		})
+	}
+	if len(byType) > 0 {
+		items["allOf"] = byType
+	}
+
+	data, err := json.MarshalIndent(schema, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal schema: %w", err)
+	}
+	return append(data, '\n'), nil
+}
+
+// schemaFor describes a Go type by its yaml tags. Unknown keys are rejected
+// like LoadTeapotYAML rejects them.
+func schemaFor(t reflect.Type, path string, enums map[string][]string) map[string]interface{} {
+	schema := make(map[string]interface{})
+	if description, ok := schemaDescriptions[path]; ok {
+		schema["description"] = description
+	}
+
+	switch t.Kind() {
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			name, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+			if name == "" || name == "-" {
+				continue
+			}
+			properties[name] = schemaFor(field.Type, strings.TrimPrefix(path+"."+name, "."), enums)
+		}
+		schema["type"] = "object"
+		schema["properties"] = properties
+		schema["additionalProperties"] = false
+		if required, ok := schemaRequired[path]; ok {
+			schema["required"] = required
+		}
+	case reflect.Slice:
+		items := schemaFor(t.Elem(), path, enums)
+		delete(items, "description")
+		schema["type"] = "array"
+		schema["items"] = items
+		return schema
+	case reflect.Map:
+		schema["type"] = "object"
+		schema["additionalProperties"] = map[string]interface{}{"type": "boolean"}
+	case reflect.Bool:
+		schema["type"] = "boolean"
+	default:
+		schema["type"] = "string"
+	}
+
+	if values := enums[path]; len(values) > 0 {
+		schema["enum"] = values
+	}
+	return schema
+}
+
+// listPattern matches an empty string or a comma-separated list of values.
+func listPattern(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = regexp.QuoteMeta(value)
+	}
+	value := "(" + strings.Join(quoted, "|") + ")"
+	return "^(" + value + "(," + value + ")*)?$"
+}
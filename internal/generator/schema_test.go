@@ -0,0 +1,112 @@
+package generator
+
+import (
+	"encoding/json"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+// testSchemaChoices returns a small set of choices for schema tests
+func testSchemaChoices() SchemaChoices {
+	return SchemaChoices{
+		Architectures:   []string{"turborepo", "single"},
+		PackageManagers: []string{"bun", "pnpm"},
+		AppTypes:        []string{"next", "nest"},
+		LintingTools:    []string{"biome"},
+		CIProviders:     []string{"github", "skip"},
+		AITools:         []string{"cursor", "none"},
+		AppOptions: map[string][]SchemaOption{
+			"next": {{Key: "tailwind", Description: "Tailwind CSS"}},
+		},
+	}
+}
+
+// schemaPath returns the nested schema object at the given keys
+func schemaPath(t *testing.T, schema map[string]interface{}, keys ...string) map[string]interface{} {
+	t.Helper()
+	current := schema
+	for _, key := range keys {
+		next, ok := current[key].(map[string]interface{})
+		if !ok {
+			t.Fatalf("Expected schema object at %v, missing %q", keys, key)
+		}
+		current = next
+	}
+	return current
+}
+
+func TestTeapotYAMLSchema(t *testing.T) {
+	data, err := TeapotYAMLSchema(testSchemaChoices())
+	if err != nil {
+		t.Fatalf("Expected schema to build, got error: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatalf("Expected valid JSON, got error: %v", err)
+	}
+
+	version := schemaPath(t, schema, "properties", "version")
+	if !reflect.DeepEqual(version["enum"], []interface{}{teapotYAMLVersion}) {
+		t.Errorf("Expected version to be %q, got %v", teapotYAMLVersion, version["enum"])
+	}
+	architecture := schemaPath(t, schema, "properties", "project", "properties", "architecture")
+	if !reflect.DeepEqual(architecture["enum"], []interface{}{"turborepo", "single"}) {
+		t.Errorf("Expected the architecture choices, got %v", architecture["enum"])
+	}
+	features := schemaPath(t, schema, "properties", "ciPipeline", "properties", "features")
+	if features["type"] != "array" || schemaPath(t, features, "items")["type"] != "string" {
+		t.Errorf("Expected features to be a list of strings, got %v", features)
+	}
+
+	pattern := regexp.MustCompile(schemaPath(t, schema, "properties", "aiTools", "properties", "editor")["pattern"].(string))
+	for value, valid := range map[string]bool{"": true, "cursor": true, "cursor,none": true, "vim": false, "cursor,": false} {
+		if pattern.MatchString(value) != valid {
+			t.Errorf("Expected editor %q valid=%v", value, valid)
+		}
+	}
+
+	items := schemaPath(t, schema, "properties", "applications", "items")
+	appName := regexp.MustCompile(schemaPath(t, items, "properties", "name")["pattern"].(string))
+	for value, valid := range map[string]bool{"web": true, "admin_v2-app": true, "../../escape": false, "a/b": false, "my app": false} {
+		if appName.MatchString(value) != valid {
+			t.Errorf("Expected application name %q valid=%v", value, valid)
+		}
+	}
+
+	byType := items["allOf"].([]interface{})
+	if len(byType) != 2 {
+		t.Fatalf("Expected option rules for 2 application types, got %d", len(byType))
+	}
+	options := schemaPath(t, byType[0].(map[string]interface{}), "then", "properties", "options")
+	if _, ok := schemaPath(t, options, "properties")["tailwind"]; !ok || options["additionalProperties"] != false {
+		t.Errorf("Expected only the next options to be allowed, got %v", options)
+	}
+}
+
+func TestTeapotYAMLSchema_DescribesEveryField(t *testing.T) {
+	data, err := TeapotYAMLSchema(testSchemaChoices())
+	if err != nil {
+		t.Fatalf("Expected schema to build, got error: %v", err)
+	}
+	var schema map[string]interface{}
+	if err := json.Unmarshal(data, &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	var check func(path string, object map[string]interface{})
+	check = func(path string, object map[string]interface{}) {
+		if items, ok := object["items"].(map[string]interface{}); ok {
+			check(path, items)
+		}
+		properties, _ := object["properties"].(map[string]interface{})
+		for name, property := range properties {
+			property := property.(map[string]interface{})
+			if _, ok := property["description"]; !ok {
+				t.Errorf("Expected %s%s to have a description", path, name)
+			}
+			check(path+name+".", property)
+		}
+	}
+	check("", schema)
+}
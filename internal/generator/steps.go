@@ -0,0 +1,356 @@
+package generator
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+
+	"teapot/internal/models"
+)
+
+// workspaceTasks are the app scripts the workspace tool runs across every app
+var workspaceTasks = []string{"build", "dev", "lint", "test"}
+
+// packageJSON models the subset of package.json fields emitted by the generator.
+// Field order here is the order in the written file.
+type packageJSON struct {
+	Name             string              `json:"name"`
+	Version          string              `json:"version"`
+	Private          bool                `json:"private"`
+	Description      string              `json:"description,omitempty"`
+	PackageManager   string              `json:"packageManager,omitempty"`
+	Type             string              `json:"type,omitempty"`
+	Exports          map[string]string   `json:"exports,omitempty"`
+	Files            []string            `json:"files,omitempty"`
+	Workspaces       []string            `json:"workspaces,omitempty"`
+	Scripts          map[string]string   `json:"scripts,omitempty"`
+	Dependencies     map[string]string   `json:"dependencies,omitempty"`
+	DevDependencies  map[string]string   `json:"devDependencies,omitempty"`
+	PeerDependencies map[string]string   `json:"peerDependencies,omitempty"`
+	LintStaged       map[string][]string `json:"lint-staged,omitempty"`
+}
+
+// IsSingleApp reports whether a project is generated as a flat repository with
+// its only application at the root instead of an apps/ workspace.
+func IsSingleApp(project models.ProjectConfig) bool {
+	return project.Architecture == models.ArchitectureSingle
+}
+
+// AppDir returns the folder of an application relative to the project root.
+func AppDir(project models.ProjectConfig, app models.Application) string {
+	if IsSingleApp(project) {
+		return "."
+	}
+	return "apps/" + AppFolderName(app)
+}
+
+// AppFolderName returns the folder name of an application, falling back to
+// the default name for its type when no name was configured.
+func AppFolderName(app models.Application) string {
+	if app.Name != "" {
+		return app.Name
+	}
+	switch app.Type {
+	case models.AppTypeExpo:
+		return "mobile"
+	case models.AppTypeNest, models.AppTypeBasicNode:
+		return "api"
+	default:
+		return "web"
+	}
+}
+
+// AppSourceFolder returns the source folder generated for an application type,
+// or an empty string when sources live directly in the application folder.
+func AppSourceFolder(appType models.AppType) string {
+	switch appType {
+	case models.AppTypeTanStack:
+		return "app"
+	case models.AppTypeExpo:
+		return ""
+	default:
+		return "src"
+	}
+}
+
+// PackageName returns the workspace package name for an application.
+func PackageName(project models.ProjectConfig, app models.Application) string {
+	if IsSingleApp(project) {
+		return strings.ToLower(project.Name)
+	}
+	return "@" + strings.ToLower(project.Name) + "/" + strings.ToLower(AppFolderName(app))
+}
+
+// IsServerApp reports whether an application type runs as a backend service.
+func IsServerApp(appType models.AppType) bool {
+	return appType == models.AppTypeNest || appType == models.AppTypeBasicNode
+}
+
+// marshalJSON renders v as indented JSON with a trailing newline.
+func marshalJSON(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(v); err != nil {
+		return nil, fmt.Errorf("failed to marshal JSON: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// textFile creates a regular file from a string.
+func textFile(path, content string) File {
+	return File{Path: path, Content: []byte(content), Mode: 0644}
+}
+
+// jsonFile creates a file containing v rendered as JSON.
+func jsonFile(path string, v interface{}) (File, error) {
+	content, err := marshalJSON(v)
+	if err != nil {
+		return File{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return File{Path: path, Content: content, Mode: 0644}, nil
+}
+
+// baseStep emits the files every project gets regardless of configuration.
+func baseStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Base project initialized", Description: "Creating project structure"}
+
+	teapotYAML, err := GenerateTeapotYAML(project)
+	if err != nil {
+		return step, err
+	}
+
+	step.Files = append(step.Files,
+		textFile("README.md", renderReadme(project)),
+		textFile(".gitignore", gitignoreContent),
+		textFile(".editorconfig", editorconfigContent),
+		textFile("teapot.yml", teapotYAML),
+	)
+	return step, nil
+}
+
+// workspaceStep emits the root workspace manifest and shared TypeScript config.
+// Single-application projects have no workspace; their root package.json is the
+// application manifest written by appsStep.
+func workspaceStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Monorepo workspace configured", Description: "Setting up workspace"}
+
+	if IsSingleApp(project) {
+		step.Name = "Project configured"
+		step.Description = "Setting up project"
+		step.Files = append(step.Files, packageManagerFiles(project)...)
+		if project.DevTools.TypeScript {
+			step.Files = append(step.Files, textFile("tsconfig.base.json", tsconfigBaseContent))
+		}
+		return step, nil
+	}
+
+	pm := ProjectPackageManager(project)
+	root := packageJSON{
+		Name:        strings.ToLower(project.Name),
+		Version:     "0.0.0",
+		Private:     true,
+		Description: project.Description,
+		Workspaces:  []string{"apps/*", "packages/*"},
+		Scripts: map[string]string{
+			"dev":   workspaceScript(pm, "dev"),
+			"build": workspaceScript(pm, "build"),
+			"lint":  workspaceScript(pm, "lint"),
+			"test":  workspaceScript(pm, "test"),
+		},
+		DevDependencies: map[string]string{},
+	}
+	if pm == models.PackageManagerPnpm {
+		root.Workspaces = nil
+	}
+
+	switch project.Architecture {
+	case models.ArchitectureTurborepo:
+		turbo := buildTurboConfig(project)
+		turboFile, err := jsonFile("turbo.json", turbo)
+		if err != nil {
+			return step, err
+		}
+		step.Files = append(step.Files, turboFile)
+
+		root.Scripts = turboScripts(turbo)
+		root.DevDependencies["turbo"] = "^2.1.0"
+	case models.ArchitectureNx:
+		nxFile, err := jsonFile("nx.json", buildNxConfig(project))
+		if err != nil {
+			return step, err
+		}
+		step.Files = append(step.Files, nxFile)
+
+		root.Scripts = nxScripts(project)
+		root.DevDependencies["nx"] = "^21.0.0"
+	}
+	applyRootTooling(project, &root)
+
+	packageFile, err := jsonFile("package.json", root)
+	if err != nil {
+		return step, err
+	}
+	step.Files = append([]File{packageFile}, step.Files...)
+	step.Files = append(step.Files, packageManagerFiles(project)...)
+
+	if project.DevTools.TypeScript {
+		step.Files = append(step.Files, textFile("tsconfig.base.json", tsconfigBaseContent))
+	}
+	return step, nil
+}
+
+// applyRootTooling adds the package manager and the repository-wide dev tooling
+// to the root package.json.
+func applyRootTooling(project models.ProjectConfig, root *packageJSON) {
+	root.PackageManager = projectPackageManager(project).spec
+	if root.DevDependencies == nil {
+		root.DevDependencies = make(map[string]string)
+	}
+	for name, version := range rootDevDependencies(project) {
+		root.DevDependencies[name] = version
+	}
+	for _, pkg := range []models.PackageType{models.PackageESLintConfig, models.PackagePrettierConfig} {
+		if hasPackage(project, pkg) {
+			root.DevDependencies[SharedPackageName(project, pkg)] = projectPackageManager(project).workspaceProtocol
+		}
+	}
+	if project.DevTools.LintStaged {
+		root.LintStaged = lintStagedConfig(project.DevTools.Linting)
+	}
+	if project.DevTools.Husky {
+		root.Scripts["prepare"] = "husky"
+	}
+}
+
+// appsStep renders the template tree of every configured application.
+func appsStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Apps scaffolded", Description: "Generating applications"}
+
+	for _, app := range project.Applications {
+		dir := AppDir(project, app)
+
+		manifest := packageJSON{
+			Name:            PackageName(project, app),
+			Version:         "0.0.0",
+			Private:         true,
+			Description:     app.Description,
+			Scripts:         appScripts(project, app),
+			Dependencies:    appDependencies(app),
+			DevDependencies: appDevDependencies(app),
+		}
+		if app.Type == models.AppTypeBasicNode {
+			manifest.Type = "module"
+		}
+		addWorkspaceDependencies(project, app, &manifest)
+		if IsSingleApp(project) {
+			manifest.Description = project.Description
+			applyRootTooling(project, &manifest)
+		}
+		packageFile, err := jsonFile(path.Join(dir, "package.json"), manifest)
+		if err != nil {
+			return step, err
+		}
+		step.Files = append(step.Files, packageFile)
+
+		if project.Architecture == models.ArchitectureNx {
+			projectFile, err := jsonFile(path.Join(dir, "project.json"), buildNxProject(project, app))
+			if err != nil {
+				return step, err
+			}
+			step.Files = append(step.Files, projectFile)
+		}
+
+		files, err := renderAppTemplates(newTemplateData(project, app), dir)
+		if err != nil {
+			return step, err
+		}
+		step.Files = append(step.Files, files...)
+	}
+	return step, nil
+}
+
+// devToolsStep emits linting, formatting and git hook configuration.
+func devToolsStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Setting up Git hooks", Description: "Configuring development tools"}
+
+	switch project.DevTools.Linting {
+	case "prettier-eslint":
+		prettierrc, eslintConfig := prettierContent, eslintContent
+		if hasPackage(project, models.PackagePrettierConfig) {
+			prettierrc = fmt.Sprintf("%q\n", SharedPackageName(project, models.PackagePrettierConfig))
+		}
+		if hasPackage(project, models.PackageESLintConfig) {
+			eslintConfig = fmt.Sprintf(sharedESLintContent, SharedPackageName(project, models.PackageESLintConfig))
+		}
+		step.Files = append(step.Files,
+			textFile(".prettierrc", prettierrc),
+			textFile("eslint.config.mjs", eslintConfig),
+		)
+	case "biome":
+		step.Files = append(step.Files, textFile("biome.json", biomeContent))
+	}
+
+	if project.DevTools.Husky {
+		hook := projectPackageManager(project).exec + " lint-staged\n"
+		if !project.DevTools.LintStaged {
+			hook = RunCommand(ProjectPackageManager(project), "lint") + "\n"
+		}
+		step.Files = append(step.Files, File{Path: ".husky/pre-commit", Content: []byte(hook), Mode: 0755})
+	}
+	return step, nil
+}
+
+// infrastructureStep emits container and infrastructure-as-code configuration.
+func infrastructureStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Infrastructure configured", Description: "Setting up infrastructure"}
+	infra := project.Infrastructure
+
+	if infra.Docker {
+		step.Files = append(step.Files,
+			textFile("Dockerfile", renderDockerfile(project)),
+			textFile(".dockerignore", dockerignoreContent),
+		)
+	}
+	if infra.DockerCompose {
+		step.Files = append(step.Files, textFile("docker-compose.yml", renderDockerCompose(project)))
+	}
+	if infra.Pulumi {
+		step.Files = append(step.Files,
+			textFile("infra/pulumi/Pulumi.yaml", fmt.Sprintf(pulumiProjectContent, strings.ToLower(project.Name))),
+			textFile("infra/pulumi/index.ts", pulumiIndexContent),
+		)
+	}
+	if infra.Terraform {
+		step.Files = append(step.Files, textFile("infra/terraform/main.tf", terraformContent))
+	}
+	return step, nil
+}
+
+// ciStep emits the CI/CD pipeline for the selected provider.
+func ciStep(project models.ProjectConfig) (Step, error) {
+	step := Step{Name: "Configuring CI/CD", Description: "Setting up automation"}
+
+	switch project.CIPipeline.Provider {
+	case "github":
+		step.Files = append(step.Files, textFile(".github/workflows/ci.yml", renderGitHubWorkflow(project)))
+	case "gitlab":
+		step.Files = append(step.Files, textFile(".gitlab-ci.yml", renderGitLabCI(project)))
+	case "jenkins":
+		step.Files = append(step.Files, textFile("Jenkinsfile", renderJenkinsfile(project)))
+	}
+	return step, nil
+}
+
+// hasFeature reports whether a CI feature was selected.
+func hasFeature(project models.ProjectConfig, feature string) bool {
+	for _, f := range project.CIPipeline.Features {
+		if f == feature {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,228 @@
+package generator
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"strings"
+	"text/template"
+
+	"teapot/internal/models"
+)
+
+// templateFS holds one template tree per application type, rooted at templates/<app type>/,
+// and one per shared package, rooted at templates/packages/<package type>/.
+// Files ending in .tmpl are rendered with text/template; files below a _partials
+// directory define named templates shared by the other templates of the same tree.
+//
+//go:embed all:templates
+var templateFS embed.FS
+
+// Template delimiters differ from the text/template defaults so that JSX
+// expressions such as style={{ flex: 1 }} can be written literally.
+const (
+	templateLeftDelim  = "[["
+	templateRightDelim = "]]"
+	templateExt        = ".tmpl"
+	partialsDir        = "_partials"
+)
+
+// TemplateData is the data passed to every application and shared package template.
+type TemplateData struct {
+	// Project is the full project configuration
+	Project models.ProjectConfig
+	// App is the application being rendered (application templates only)
+	App models.Application
+	// Package is the shared package being rendered (package templates only)
+	Package models.PackageType
+	// PackageName is the workspace package name of the application or package
+	PackageName string
+	// Title is a human-readable name for the application
+	Title string
+	// Slug is a lowercase, URL-safe identifier for the application
+	Slug string
+	// Description is the application description, or the project description if empty
+	Description string
+	// RootPath is the relative path from the application folder to the project root
+	RootPath string
+	// TSConfigBase is the tsconfig extended by the application, empty without TypeScript
+	TSConfigBase string
+	// WorkspacePackages lists the shared runtime packages the application depends on
+	WorkspacePackages []string
+}
+
+// Option reports whether a boolean application option is enabled.
+// Templates use it to include files and blocks, e.g. [[if .Option "tailwind"]].
+func (d TemplateData) Option(key string) bool {
+	return appOption(d.App, key)
+}
+
+// newTemplateData builds the template data for an application.
+func newTemplateData(project models.ProjectConfig, app models.Application) TemplateData {
+	description := app.Description
+	if description == "" {
+		description = project.Description
+	}
+
+	rootPath := "../.."
+	if IsSingleApp(project) {
+		rootPath = "."
+	}
+
+	var workspacePackages []string
+	for _, pkg := range appPackages(project, app) {
+		if !isConfigPackage(pkg) {
+			workspacePackages = append(workspacePackages, SharedPackageName(project, pkg))
+		}
+	}
+
+	return TemplateData{
+		Project:           project,
+		App:               app,
+		PackageName:       PackageName(project, app),
+		Title:             project.Name + " " + AppFolderName(app),
+		Slug:              strings.ToLower(project.Name + "-" + AppFolderName(app)),
+		Description:       description,
+		RootPath:          rootPath,
+		TSConfigBase:      tsconfigBase(project, rootPath),
+		WorkspacePackages: workspacePackages,
+	}
+}
+
+// newPackageTemplateData builds the template data for a shared package.
+func newPackageTemplateData(project models.ProjectConfig, pkg models.PackageType) TemplateData {
+	return TemplateData{
+		Project:      project,
+		Package:      pkg,
+		PackageName:  SharedPackageName(project, pkg),
+		Title:        project.Name + " " + string(pkg),
+		Slug:         strings.ToLower(project.Name + "-" + string(pkg)),
+		Description:  models.PackageTypeNames[pkg],
+		RootPath:     "../..",
+		TSConfigBase: tsconfigBase(project, "../.."),
+	}
+}
+
+// tsconfigBase returns the tsconfig extended by generated code: the shared tsconfig
+// package when selected, otherwise the root tsconfig.base.json.
+func tsconfigBase(project models.ProjectConfig, rootPath string) string {
+	if hasPackage(project, models.PackageTSConfig) {
+		return SharedPackageName(project, models.PackageTSConfig) + "/base.json"
+	}
+	if project.DevTools.TypeScript {
+		return rootPath + "/tsconfig.base.json"
+	}
+	return ""
+}
+
+// appOption reports whether a boolean option is enabled on an application.
+func appOption(app models.Application, key string) bool {
+	enabled, ok := app.Options[key].(bool)
+	return ok && enabled
+}
+
+// templateFuncs are the helper functions available inside templates.
+var templateFuncs = template.FuncMap{
+	"lower": strings.ToLower,
+	"upper": strings.ToUpper,
+	"json":  toJSON,
+}
+
+// toJSON renders a value as compact JSON for embedding in generated code.
+func toJSON(v interface{}) (string, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// renderAppTemplates renders the template tree of an application into files below dir.
+func renderAppTemplates(data TemplateData, dir string) ([]File, error) {
+	return renderTemplateTree(path.Join("templates", string(data.App.Type)), data, dir)
+}
+
+// renderPackageTemplates renders the template tree of a shared package into files below dir.
+func renderPackageTemplates(data TemplateData, dir string) ([]File, error) {
+	return renderTemplateTree(path.Join("templates", "packages", string(data.Package)), data, dir)
+}
+
+// renderTemplateTree renders every template below root into files below dir.
+// Templates that render to whitespace only are treated as disabled and skipped,
+// which is how conditional files are expressed.
+func renderTemplateTree(root string, data TemplateData, dir string) ([]File, error) {
+	var sources, partials []string
+	err := fs.WalkDir(templateFS, root, func(name string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || !strings.HasSuffix(name, templateExt) {
+			return nil
+		}
+		if strings.HasPrefix(name, root+"/"+partialsDir+"/") {
+			partials = append(partials, name)
+		} else {
+			sources = append(sources, name)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("no templates in %s: %w", root, err)
+	}
+
+	var files []File
+	for _, source := range sources {
+		content, err := renderTemplate(source, partials, data)
+		if err != nil {
+			return nil, err
+		}
+		if len(bytes.TrimSpace(content)) == 0 {
+			continue
+		}
+
+		version, err := templateVersion(append(append([]string{}, partials...), source))
+		if err != nil {
+			return nil, err
+		}
+
+		rel := strings.TrimSuffix(strings.TrimPrefix(source, root+"/"), templateExt)
+		files = append(files, File{
+			Path:            path.Join(dir, rel),
+			Content:         content,
+			Mode:            0644,
+			Template:        strings.TrimPrefix(source, "templates/"),
+			TemplateVersion: version,
+		})
+	}
+	return files, nil
+}
+
+// renderTemplate parses a single template together with its partials and executes it.
+func renderTemplate(source string, partials []string, data TemplateData) ([]byte, error) {
+	id := strings.TrimPrefix(source, "templates/")
+
+	tmpl := template.New(id).Delims(templateLeftDelim, templateRightDelim).Funcs(templateFuncs)
+	names := append(append([]string{}, partials...), source)
+	for _, name := range names {
+		text, err := templateFS.ReadFile(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template %s: %w", name, err)
+		}
+		target := tmpl
+		if name != source {
+			target = tmpl.New(strings.TrimPrefix(name, "templates/"))
+		}
+		if _, err := target.Parse(string(text)); err != nil {
+			return nil, fmt.Errorf("failed to parse template %s: %w", name, err)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&buf, id, data); err != nil {
+		return nil, fmt.Errorf("failed to render template %s: %w", id, err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,117 @@
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+// renderPaths renders an application and returns its files keyed by path
+func renderPaths(t *testing.T, app models.Application) map[string]File {
+	t.Helper()
+
+	project := testProject()
+	project.Applications = []models.Application{app}
+
+	files, err := renderAppTemplates(newTemplateData(project, app), AppDir(project, app))
+	if err != nil {
+		t.Fatalf("Expected templates to render, got error: %v", err)
+	}
+
+	paths := make(map[string]File)
+	for _, file := range files {
+		paths[file.Path] = file
+	}
+	return paths
+}
+
+func TestRenderAppTemplates_AllTypes(t *testing.T) {
+	types := []models.AppType{
+		models.AppTypeReact,
+		models.AppTypeNext,
+		models.AppTypeTanStack,
+		models.AppTypeExpo,
+		models.AppTypeNest,
+		models.AppTypeBasicNode,
+	}
+
+	for _, appType := range types {
+		app := models.Application{Type: appType, Options: map[string]interface{}{}}
+		files := renderPaths(t, app)
+		if len(files) == 0 {
+			t.Errorf("Expected templates for %s", appType)
+		}
+		for path, file := range files {
+			if !strings.HasPrefix(file.Template, string(appType)+"/") {
+				t.Errorf("Expected %s to record its source template, got %q", path, file.Template)
+			}
+			if strings.Contains(path, partialsDir) {
+				t.Errorf("Expected partial %s not to be emitted", path)
+			}
+		}
+	}
+}
+
+func TestRenderAppTemplates_ConditionalFiles(t *testing.T) {
+	tests := []struct {
+		name     string
+		app      models.Application
+		present  []string
+		absent   []string
+		contains map[string]string
+	}{
+		{
+			name:    "next with app router and tailwind",
+			app:     models.Application{Name: "web", Type: models.AppTypeNext, Options: map[string]interface{}{"app-router": true, "tailwind": true}},
+			present: []string{"apps/web/src/app/layout.tsx", "apps/web/tailwind.config.ts"},
+			absent:  []string{"apps/web/src/pages/_app.tsx"},
+		},
+		{
+			name:    "next with pages router",
+			app:     models.Application{Name: "web", Type: models.AppTypeNext, Options: map[string]interface{}{}},
+			present: []string{"apps/web/src/pages/_app.tsx", "apps/web/src/pages/index.tsx"},
+			absent:  []string{"apps/web/src/app/layout.tsx", "apps/web/tailwind.config.ts"},
+		},
+		{
+			name:     "nest with swagger",
+			app:      models.Application{Name: "api", Type: models.AppTypeNest, Options: map[string]interface{}{"swagger": true}},
+			present:  []string{"apps/api/src/main.ts"},
+			absent:   []string{"apps/api/prisma/schema.prisma"},
+			contains: map[string]string{"apps/api/src/main.ts": "SwaggerModule"},
+		},
+		{
+			name:     "basic node without typescript",
+			app:      models.Application{Name: "worker", Type: models.AppTypeBasicNode, Options: map[string]interface{}{"express": true}},
+			present:  []string{"apps/worker/src/index.js"},
+			absent:   []string{"apps/worker/src/index.ts", "apps/worker/tsconfig.json"},
+			contains: map[string]string{"apps/worker/src/index.js": "express"},
+		},
+		{
+			name:    "tanstack root route",
+			app:     models.Application{Name: "web", Type: models.AppTypeTanStack, Options: map[string]interface{}{}},
+			present: []string{"apps/web/app/routes/__root.tsx"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			files := renderPaths(t, tt.app)
+			for _, path := range tt.present {
+				if _, ok := files[path]; !ok {
+					t.Errorf("Expected %s to be rendered", path)
+				}
+			}
+			for _, path := range tt.absent {
+				if _, ok := files[path]; ok {
+					t.Errorf("Expected %s not to be rendered", path)
+				}
+			}
+			for path, text := range tt.contains {
+				if !strings.Contains(string(files[path].Content), text) {
+					t.Errorf("Expected %s to contain %q", path, text)
+				}
+			}
+		})
+	}
+}
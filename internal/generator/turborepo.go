@@ -0,0 +1,131 @@
+package generator
+
+import (
+	"teapot/internal/models"
+)
+
+// turboSchemaURL is the JSON schema referenced by the generated turbo.json
+const turboSchemaURL = "https://turbo.build/schema.json"
+
+// turboConfig models the subset of turbo.json emitted by the generator.
+type turboConfig struct {
+	Schema string               `json:"$schema"`
+	UI     string               `json:"ui,omitempty"`
+	Tasks  map[string]turboTask `json:"tasks"`
+}
+
+// turboTask models a single task definition in turbo.json.
+type turboTask struct {
+	DependsOn  []string `json:"dependsOn,omitempty"`
+	Outputs    []string `json:"outputs,omitempty"`
+	Cache      *bool    `json:"cache,omitempty"`
+	Persistent bool     `json:"persistent,omitempty"`
+}
+
+// buildTurboConfig derives the turbo.json tasks from the scripts of the configured apps.
+// Client apps get a package-specific build task that waits for every server app's
+// build, so e.g. a Nest API is built before the Next.js app that consumes it.
+func buildTurboConfig(project models.ProjectConfig) turboConfig {
+	config := turboConfig{
+		Schema: turboSchemaURL,
+		UI:     "tui",
+		Tasks:  make(map[string]turboTask),
+	}
+
+	var serverBuilds []string
+	var buildOutputs []string
+	for _, app := range project.Applications {
+		scripts := appScripts(project, app)
+		if _, ok := scripts["build"]; !ok {
+			continue
+		}
+		buildOutputs = appendUnique(buildOutputs, appBuildOutputs(app)...)
+		if IsServerApp(app.Type) {
+			serverBuilds = append(serverBuilds, PackageName(project, app)+"#build")
+		}
+	}
+
+	for _, task := range workspaceTasks {
+		if !appsHaveScript(project, task) {
+			continue
+		}
+		switch task {
+		case "build":
+			config.Tasks[task] = turboTask{DependsOn: []string{"^build"}, Outputs: buildOutputs}
+		case "dev":
+			noCache := false
+			config.Tasks[task] = turboTask{Cache: &noCache, Persistent: true}
+		case "lint":
+			config.Tasks[task] = turboTask{DependsOn: []string{"^lint"}}
+		case "test":
+			config.Tasks[task] = turboTask{DependsOn: []string{"^build"}}
+		}
+	}
+
+	if len(serverBuilds) == 0 {
+		return config
+	}
+	for _, app := range project.Applications {
+		if IsServerApp(app.Type) {
+			continue
+		}
+		if _, ok := appScripts(project, app)["build"]; !ok {
+			continue
+		}
+		config.Tasks[PackageName(project, app)+"#build"] = turboTask{
+			DependsOn: append([]string{"^build"}, serverBuilds...),
+			Outputs:   appBuildOutputs(app),
+		}
+	}
+	return config
+}
+
+// turboScripts returns the root package.json scripts that run each turbo task.
+func turboScripts(config turboConfig) map[string]string {
+	scripts := make(map[string]string)
+	for _, task := range workspaceTasks {
+		if _, ok := config.Tasks[task]; ok {
+			scripts[task] = "turbo run " + task
+		}
+	}
+	return scripts
+}
+
+// appBuildOutputs returns the folders produced by an application's build script.
+func appBuildOutputs(app models.Application) []string {
+	switch app.Type {
+	case models.AppTypeNext:
+		return []string{".next/**", "!.next/cache/**"}
+	case models.AppTypeTanStack:
+		return []string{".output/**", ".vinxi/**"}
+	default:
+		return []string{"dist/**"}
+	}
+}
+
+// appsHaveScript reports whether any configured application defines a script.
+func appsHaveScript(project models.ProjectConfig, script string) bool {
+	for _, app := range project.Applications {
+		if _, ok := appScripts(project, app)[script]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// appendUnique appends the values not already present in slice.
+func appendUnique(slice []string, values ...string) []string {
+	for _, value := range values {
+		found := false
+		for _, existing := range slice {
+			if existing == value {
+				found = true
+				break
+			}
+		}
+		if !found {
+			slice = append(slice, value)
+		}
+	}
+	return slice
+}
@@ -0,0 +1,108 @@
+package generator
+
+import (
+	"encoding/json"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+func TestBuildTurboConfig_Tasks(t *testing.T) {
+	config := buildTurboConfig(testProject())
+
+	for _, task := range []string{"build", "dev", "lint", "test"} {
+		if _, ok := config.Tasks[task]; !ok {
+			t.Errorf("Expected turbo.json to define task %s", task)
+		}
+	}
+
+	dev := config.Tasks["dev"]
+	if dev.Cache == nil || *dev.Cache || !dev.Persistent {
+		t.Error("Expected dev task to be persistent and uncached")
+	}
+
+	outputs := map[string]bool{}
+	for _, output := range config.Tasks["build"].Outputs {
+		outputs[output] = true
+	}
+	if !outputs[".next/**"] || !outputs["dist/**"] {
+		t.Errorf("Expected build outputs for Next.js and Nest apps, got %v", config.Tasks["build"].Outputs)
+	}
+}
+
+func TestBuildTurboConfig_ServerBuildsFirst(t *testing.T) {
+	config := buildTurboConfig(testProject())
+
+	web, ok := config.Tasks["@test-project/web#build"]
+	if !ok {
+		t.Fatal("Expected a package-specific build task for the web app")
+	}
+
+	found := false
+	for _, dep := range web.DependsOn {
+		if dep == "@test-project/api#build" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("Expected web build to depend on api build, got %v", web.DependsOn)
+	}
+
+	if _, ok := config.Tasks["@test-project/api#build"]; ok {
+		t.Error("Expected server apps to use the generic build task")
+	}
+}
+
+func TestBuildTurboConfig_ClientOnly(t *testing.T) {
+	project := testProject()
+	project.Applications = []models.Application{
+		{Name: "web", Type: models.AppTypeReact},
+		{Name: "mobile", Type: models.AppTypeExpo},
+	}
+
+	config := buildTurboConfig(project)
+	if len(config.Tasks) != len(workspaceTasks) {
+		t.Errorf("Expected only generic tasks without server apps, got %d tasks", len(config.Tasks))
+	}
+}
+
+func TestWorkspaceStep_Turborepo(t *testing.T) {
+	step, err := workspaceStep(testProject())
+	if err != nil {
+		t.Fatalf("Expected workspace step to build, got error: %v", err)
+	}
+
+	files := map[string]File{}
+	for _, file := range step.Files {
+		files[file.Path] = file
+	}
+	if _, ok := files["turbo.json"]; !ok {
+		t.Fatal("Expected turbo.json for a Turborepo project")
+	}
+
+	var root packageJSON
+	if err := json.Unmarshal(files["package.json"].Content, &root); err != nil {
+		t.Fatalf("Expected valid root package.json, got error: %v", err)
+	}
+	if root.Scripts["build"] != "turbo run build" {
+		t.Errorf("Expected build script to run turbo, got %q", root.Scripts["build"])
+	}
+	if root.DevDependencies["turbo"] == "" {
+		t.Error("Expected turbo as a root dev dependency")
+	}
+	if root.PackageManager == "" {
+		t.Error("Expected packageManager to be set for Turborepo")
+	}
+
+	project := testProject()
+	project.Architecture = models.ArchitectureNx
+	step, err = workspaceStep(project)
+	if err != nil {
+		t.Fatalf("Expected workspace step to build, got error: %v", err)
+	}
+	for _, file := range step.Files {
+		if file.Path == "turbo.json" {
+			t.Error("Expected no turbo.json for a non-Turborepo project")
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package generator
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"teapot/internal/models"
+)
+
+// UpgradeStatus describes what an upgrade does with a single file.
+type UpgradeStatus int
+
+const (
+	// UpgradeUnchanged means the file already matches the current templates
+	UpgradeUnchanged UpgradeStatus = iota
+	// UpgradeAdded means the current templates generate a file the project does not have yet
+	UpgradeAdded
+	// UpgradeUpdated means an untouched generated file is replaced with the new render
+	UpgradeUpdated
+	// UpgradeKept means local edits are kept because the templates did not change the file
+	UpgradeKept
+	// UpgradeMerged means local edits and template changes were merged cleanly
+	UpgradeMerged
+	// UpgradeConflict means overlapping changes were written between conflict markers
+	UpgradeConflict
+	// UpgradeSkipped means a generated file was deleted locally and is not restored
+	UpgradeSkipped
+	// UpgradeReplaced means a file of a project without recorded generated
+	// content is replaced, keeping a backup of the previous content
+	UpgradeReplaced
+)
+
+// String returns the label used in upgrade reports
+func (s UpgradeStatus) String() string {
+	switch s {
+	case UpgradeAdded:
+		return "added"
+	case UpgradeUpdated:
+		return "updated"
+	case UpgradeKept:
+		return "kept local changes"
+	case UpgradeMerged:
+		return "merged"
+	case UpgradeConflict:
+		return "conflict"
+	case UpgradeSkipped:
+		return "deleted locally, not restored"
+	case UpgradeReplaced:
+		return "replaced, backup kept"
+	default:
+		return "unchanged"
+	}
+}
+
+// UpgradeFile is the outcome of upgrading a single file.
+type UpgradeFile struct {
+	// Path is the slash-separated path relative to the project root
+	Path string
+	// Status describes what the upgrade does with the file
+	Status UpgradeStatus
+	// Conflicts is the number of conflicting hunks (UpgradeConflict only)
+	Conflicts int
+	// content is the file content written by Apply
+	content []byte
+	// mode is the file permission used when writing
+	mode os.FileMode
+}
+
+// Upgrade is the result of comparing a generated project with the current templates.
+type Upgrade struct {
+	// Files lists the outcome for every file the current templates generate
+	Files []UpgradeFile
+	// Orphaned lists generated files the current templates no longer produce; they are left alone
+	Orphaned []string
+	// Backup is the folder below the project root that keeps the previous
+	// content of replaced files, empty when no file is replaced
+	Backup string
+	root   string
+	plan   *Plan
+	// records lists the paths whose manifest and generated content entries
+	// Apply rewrites; nil rewrites the records of the whole project
+	records map[string]bool
+}
+
+// PlanUpgrade re-renders the templates for project and three-way merges every
+// file against the content teapot originally generated and the working file in
+// root. Nothing is written until Apply is called.
+func PlanUpgrade(root string, project models.ProjectConfig) (*Upgrade, error) {
+	plan, err := BuildPlan(project)
+	if err != nil {
+		return nil, err
+	}
+	base, err := LoadBase(root)
+	if err != nil {
+		return nil, fmt.Errorf("%s is not an upgradable teapot project: %w", root, err)
+	}
+
+	upgrade := &Upgrade{root: root, plan: plan}
+	planned := make(map[string]bool)
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			planned[file.Path] = true
+			if isMetadata(file.Path) {
+				continue
+			}
+			result, err := upgradeFile(root, file, base)
+			if err != nil {
+				return nil, err
+			}
+			upgrade.Files = append(upgrade.Files, result)
+		}
+	}
+
+	for path := range base.Files {
+		if !planned[path] {
+			upgrade.Orphaned = append(upgrade.Orphaned, path)
+		}
+	}
+	sort.Strings(upgrade.Orphaned)
+	return upgrade, nil
+}
+
+// planChange plans the part of a project in root that changes from before to
+// after: the files whose render differs between the two configurations, such as
+// the folder of an added application and the workspace, CI, compose and
+// teapot.yml files listing applications. Files rendered the same way for both
+// are left alone, together with their local edits and template changes.
+// Projects without recorded generated content cannot be merged, so their
+// changed files are replaced and the previous content is backed up.
+func planChange(root string, before, after models.ProjectConfig) (*Upgrade, error) {
+	previous, err := BuildPlan(before)
+	if err != nil {
+		return nil, err
+	}
+	plan, err := BuildPlan(after)
+	if err != nil {
+		return nil, err
+	}
+	base, err := LoadBase(root)
+	if errors.Is(err, fs.ErrNotExist) {
+		base = nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	rendered := make(map[string][]byte)
+	for _, step := range previous.Steps {
+		for _, file := range step.Files {
+			rendered[file.Path] = file.Content
+		}
+	}
+
+	upgrade := &Upgrade{root: root, plan: plan, records: make(map[string]bool)}
+	for _, step := range plan.Steps {
+		for _, file := range step.Files {
+			content, existed := rendered[file.Path]
+			delete(rendered, file.Path)
+			if isMetadata(file.Path) || (existed && bytes.Equal(content, file.Content)) {
+				continue
+			}
+
+			var result UpgradeFile
+			if base != nil {
+				result, err = upgradeFile(root, file, base)
+			} else {
+				result, err = replaceFile(root, file)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if result.Status == UpgradeReplaced && upgrade.Backup == "" {
+				upgrade.Backup = ".teapot/backup-" + time.Now().Format("20060102-150405")
+			}
+			upgrade.Files = append(upgrade.Files, result)
+			upgrade.records[file.Path] = true
+		}
+	}
+
+	// Files only the previous configuration generates are dropped from the records
+	for path := range rendered {
+		if isMetadata(path) {
+			continue
+		}
+		upgrade.Orphaned = append(upgrade.Orphaned, path)
+		upgrade.records[path] = true
+	}
+	sort.Strings(upgrade.Orphaned)
+	return upgrade, nil
+}
+
+// replaceFile decides how a planned file is written into a project without
+// recorded generated content, where local edits cannot be merged.
+func replaceFile(root string, file File) (UpgradeFile, error) {
+	result := UpgradeFile{Path: file.Path, content: file.Content, mode: file.Mode}
+
+	current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(file.Path)))
+	switch {
+	case os.IsNotExist(err):
+		result.Status = UpgradeAdded
+	case err != nil:
+		return result, fmt.Errorf("failed to read %s: %w", file.Path, err)
+	case bytes.Equal(current, file.Content):
+		result.Status = UpgradeUnchanged
+	default:
+		result.Status = UpgradeReplaced
+	}
+	return result, nil
+}
+
+// upgradeFile decides how a single planned file is upgraded.
+func upgradeFile(root string, file File, base *Base) (UpgradeFile, error) {
+	result := UpgradeFile{Path: file.Path, content: file.Content, mode: file.Mode}
+
+	current, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(file.Path)))
+	original, generated := base.Files[file.Path]
+	switch {
+	case os.IsNotExist(err) && generated:
+		result.Status = UpgradeSkipped
+		return result, nil
+	case os.IsNotExist(err):
+		result.Status = UpgradeAdded
+		return result, nil
+	case err != nil:
+		return result, fmt.Errorf("failed to read %s: %w", file.Path, err)
+	}
+
+	switch {
+	case bytes.Equal(current, file.Content):
+		result.Status = UpgradeUnchanged
+	case generated && string(current) == original:
+		result.Status = UpgradeUpdated
+	case generated && string(file.Content) == original:
+		result.Status = UpgradeKept
+		result.content = current
+	default:
+		// Files teapot did not generate before are merged against an empty ancestor
+		merged, conflicts := Merge3(original, string(current), string(file.Content))
+		result.content = []byte(merged)
+		result.Status = UpgradeMerged
+		if conflicts > 0 {
+			result.Status = UpgradeConflict
+			result.Conflicts = conflicts
+		}
+	}
+	return result, nil
+}
+
+// Count returns the number of files with the given status.
+func (u *Upgrade) Count(status UpgradeStatus) int {
+	count := 0
+	for _, file := range u.Files {
+		if file.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// Apply writes every added, updated, merged, conflicting and replaced file and
+// records the new renders as the generated content for the next upgrade.
+// Replaced files are copied to the backup folder first.
+func (u *Upgrade) Apply() error {
+	for _, file := range u.Files {
+		if file.Status != UpgradeReplaced {
+			continue
+		}
+		current, err := os.ReadFile(filepath.Join(u.root, filepath.FromSlash(file.Path)))
+		if err != nil {
+			return fmt.Errorf("failed to back up %s: %w", file.Path, err)
+		}
+		if err := writeFile(u.root, File{Path: u.Backup + "/" + file.Path, Content: current}); err != nil {
+			return err
+		}
+	}
+
+	for _, file := range u.Files {
+		switch file.Status {
+		case UpgradeAdded, UpgradeUpdated, UpgradeMerged, UpgradeConflict, UpgradeReplaced:
+			if err := writeFile(u.root, File{Path: file.Path, Content: file.content, Mode: file.mode}); err != nil {
+				return err
+			}
+		}
+	}
+
+	for _, step := range u.plan.Steps {
+		for _, file := range step.Files {
+			if !isMetadata(file.Path) {
+				continue
+			}
+			if u.records != nil {
+				var err error
+				if file, err = mergeMetadata(u.root, file, u.records); err != nil {
+					return err
+				}
+			}
+			if err := writeFile(u.root, file); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+package generator
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeProjectFile overwrites a file of a generated project
+func writeProjectFile(t *testing.T, root, path, content string) {
+	t.Helper()
+	if err := writeFile(root, File{Path: path, Content: []byte(content)}); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// readProjectFile reads a file of a generated project
+func readProjectFile(t *testing.T, root, path string) string {
+	t.Helper()
+	content, err := os.ReadFile(filepath.Join(root, filepath.FromSlash(path)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(content)
+}
+
+func TestPlanUpgrade(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "demo")
+	if err := NewEngine(testProject(), root).Run(nil); err != nil {
+		t.Fatalf("Expected generation to succeed, got error: %v", err)
+	}
+	plan, _ := BuildPlan(testProject())
+	renders := planFiles(plan)
+	base, err := LoadBase(root)
+	if err != nil {
+		t.Fatalf("Expected generated content to load, got error: %v", err)
+	}
+
+	// Simulate a project generated by older templates and edited since
+	render := renders["apps/web/package.json"].Content
+	lines := splitLines(string(render))
+	older := append([]string{lines[0], "  \"old\": true,\n"}, lines[2:]...)
+	base.Files["apps/web/package.json"] = strings.Join(older, "")
+	writeProjectFile(t, root, "apps/web/package.json", strings.Join(older, "")+"local\n")
+
+	base.Files["README.md"] = "old readme\n"
+	writeProjectFile(t, root, "README.md", "old readme\n")
+
+	writeProjectFile(t, root, "package.json", string(renders["package.json"].Content)+"local\n")
+
+	base.Files["Dockerfile"] = "FROM old\n"
+	writeProjectFile(t, root, "Dockerfile", "FROM local\n")
+
+	if err := os.Remove(filepath.Join(root, ".husky", "pre-commit")); err != nil {
+		t.Fatal(err)
+	}
+	delete(base.Files, "docker-compose.yml")
+	if err := os.Remove(filepath.Join(root, "docker-compose.yml")); err != nil {
+		t.Fatal(err)
+	}
+	base.Files["legacy.txt"] = "no longer generated\n"
+
+	data, err := marshalJSON(base)
+	if err != nil {
+		t.Fatal(err)
+	}
+	writeProjectFile(t, root, BasePath, string(data))
+
+	upgrade, err := PlanUpgrade(root, testProject())
+	if err != nil {
+		t.Fatalf("Expected upgrade to plan, got error: %v", err)
+	}
+
+	statuses := make(map[string]UpgradeStatus)
+	for _, file := range upgrade.Files {
+		statuses[file.Path] = file.Status
+	}
+	expected := map[string]UpgradeStatus{
+		"apps/web/package.json": UpgradeMerged,
+		"README.md":             UpgradeUpdated,
+		"package.json":          UpgradeKept,
+		"Dockerfile":            UpgradeConflict,
+		".husky/pre-commit":     UpgradeSkipped,
+		"docker-compose.yml":    UpgradeAdded,
+		"turbo.json":            UpgradeUnchanged,
+	}
+	for path, status := range expected {
+		if statuses[path] != status {
+			t.Errorf("Expected %s to be %v, got %v", path, status, statuses[path])
+		}
+	}
+	if len(upgrade.Orphaned) != 1 || upgrade.Orphaned[0] != "legacy.txt" {
+		t.Errorf("Expected legacy.txt to be reported as no longer generated, got %v", upgrade.Orphaned)
+	}
+
+	if err := upgrade.Apply(); err != nil {
+		t.Fatalf("Expected upgrade to apply, got error: %v", err)
+	}
+
+	if merged := readProjectFile(t, root, "apps/web/package.json"); merged != string(render)+"local\n" {
+		t.Errorf("Expected template change and local edit to be merged, got:\n%s", merged)
+	}
+	if readme := readProjectFile(t, root, "README.md"); readme != string(renders["README.md"].Content) {
+		t.Error("Expected the untouched README.md to be updated")
+	}
+	if !strings.HasSuffix(readProjectFile(t, root, "package.json"), "local\n") {
+		t.Error("Expected local package.json edits to be kept")
+	}
+	if dockerfile := readProjectFile(t, root, "Dockerfile"); !strings.Contains(dockerfile, "<<<<<<< local\nFROM local\n=======\n") {
+		t.Errorf("Expected conflict markers in Dockerfile, got:\n%s", dockerfile)
+	}
+	if _, err := os.Stat(filepath.Join(root, ".husky", "pre-commit")); !os.IsNotExist(err) {
+		t.Error("Expected a locally deleted file not to be restored")
+	}
+	readProjectFile(t, root, "docker-compose.yml")
+
+	// The new renders become the ancestor for the next upgrade
+	base, err = LoadBase(root)
+	if err != nil {
+		t.Fatalf("Expected generated content to load, got error: %v", err)
+	}
+	if base.Files["Dockerfile"] != string(renders["Dockerfile"].Content) {
+		t.Error("Expected the new render to be recorded as generated content")
+	}
+}
+
+func TestPlanUpgrade_NotGenerated(t *testing.T) {
+	if _, err := PlanUpgrade(t.TempDir(), testProject()); err == nil {
+		t.Error("Expected upgrading a directory without generated content to fail")
+	}
+}
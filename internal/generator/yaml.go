@@ -3,21 +3,29 @@
 package generator
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
+	"io"
 	"os"
 	"path/filepath"
 	"strings"
 
+	errs "teapot/internal/errors"
 	"teapot/internal/models"
 	"gopkg.in/yaml.v3"
 )
 
+// teapotYAMLVersion is the teapot.yml format version written by this release.
+// Older versions are migrated when loaded, see migrations.
+const teapotYAMLVersion = "2.0"
+
 // TeapotConfig represents the complete configuration for a Teapot project
 type TeapotConfig struct {
 	Version     string                `yaml:"version"`
 	Project     ProjectConfig         `yaml:"project"`
-	Architecture string               `yaml:"architecture"`
 	Applications []ApplicationConfig  `yaml:"applications"`
+	Packages    *[]string            `yaml:"packages,omitempty"`
 	DevTools    DevToolsConfig       `yaml:"devTools"`
 	Infrastructure InfrastructureConfig `yaml:"infrastructure"`
 	CIPipeline  CIPipelineConfig     `yaml:"ciPipeline"`
@@ -27,6 +35,8 @@ type TeapotConfig struct {
 type ProjectConfig struct {
 	Name        string `yaml:"name"`
 	Description string `yaml:"description"`
+	Architecture string `yaml:"architecture"`
+	PackageManager string `yaml:"packageManager,omitempty"`
 }
 
 type ApplicationConfig struct {
@@ -60,16 +70,16 @@ type AIToolsConfig struct {
 	Extensions []string `yaml:"extensions"`
 }
 
-// GenerateTeapotYAML generates a teapot.yml file from the project configuration
-func GenerateTeapotYAML(project models.ProjectConfig) (string, error) {
-	// Convert models.ProjectConfig to TeapotConfig
+// newTeapotConfig converts a project configuration into its teapot.yml representation
+func newTeapotConfig(project models.ProjectConfig) TeapotConfig {
 	config := TeapotConfig{
-		Version: "1.0",
+		Version: teapotYAMLVersion,
 		Project: ProjectConfig{
 			Name:        project.Name,
 			Description: project.Description,
+			Architecture: string(project.Architecture),
+			PackageManager: string(project.PackageManager),
 		},
-		Architecture: string(project.Architecture),
 		Applications: make([]ApplicationConfig, len(project.Applications)),
 		DevTools: DevToolsConfig{
 			Linting:    project.DevTools.Linting,
@@ -93,6 +103,16 @@ func GenerateTeapotYAML(project models.ProjectConfig) (string, error) {
 		},
 	}
 
+	// Convert shared packages. An empty list is written as packages: [] so that
+	// a project without shared packages stays distinct from an unset list.
+	if project.Packages != nil {
+		packages := make([]string, 0, len(project.Packages))
+		for _, pkg := range project.Packages {
+			packages = append(packages, string(pkg))
+		}
+		config.Packages = &packages
+	}
+
 	// Convert applications
 	for i, app := range project.Applications {
 		config.Applications[i] = ApplicationConfig{
@@ -103,6 +123,71 @@ func GenerateTeapotYAML(project models.ProjectConfig) (string, error) {
 		}
 	}
 
+	return config
+}
+
+// toProjectConfig converts the teapot.yml representation back into a project configuration.
+// Empty lists become nil so that a loaded config matches the one the wizard produces,
+// except packages: an empty list means no shared packages, while nil means unset.
+func (c TeapotConfig) toProjectConfig() models.ProjectConfig {
+	project := models.ProjectConfig{
+		Name:         c.Project.Name,
+		Description:  c.Project.Description,
+		Architecture: models.ArchitectureType(c.Project.Architecture),
+		PackageManager: models.PackageManagerType(c.Project.PackageManager),
+		DevTools: models.DevTools{
+			Linting:    c.DevTools.Linting,
+			TypeScript: c.DevTools.TypeScript,
+			Husky:      c.DevTools.Husky,
+			LintStaged: c.DevTools.LintStaged,
+		},
+		Infrastructure: models.Infrastructure{
+			Docker:        c.Infrastructure.Docker,
+			DockerCompose: c.Infrastructure.DockerCompose,
+			Pulumi:        c.Infrastructure.Pulumi,
+			Terraform:     c.Infrastructure.Terraform,
+		},
+		CIPipeline: models.CIPipeline{
+			Provider: c.CIPipeline.Provider,
+			Features: nilIfEmpty(c.CIPipeline.Features),
+		},
+		AITools: models.AITools{
+			Editor:     c.AITools.Editor,
+			Extensions: nilIfEmpty(c.AITools.Extensions),
+		},
+	}
+
+	if c.Packages != nil {
+		project.Packages = make([]models.PackageType, 0, len(*c.Packages))
+		for _, pkg := range *c.Packages {
+			project.Packages = append(project.Packages, models.PackageType(pkg))
+		}
+	}
+
+	for _, app := range c.Applications {
+		project.Applications = append(project.Applications, models.Application{
+			ID:      app.ID,
+			Name:    app.Name,
+			Type:    models.AppType(app.Type),
+			Options: app.Options,
+		})
+	}
+
+	return project
+}
+
+// nilIfEmpty normalizes an empty list to nil
+func nilIfEmpty(values []string) []string {
+	if len(values) == 0 {
+		return nil
+	}
+	return values
+}
+
+// GenerateTeapotYAML generates a teapot.yml file from the project configuration
+func GenerateTeapotYAML(project models.ProjectConfig) (string, error) {
+	config := newTeapotConfig(project)
+
 	// Generate YAML content
 	yamlData, err := yaml.Marshal(&config)
 	if err != nil {
@@ -112,8 +197,69 @@ func GenerateTeapotYAML(project models.ProjectConfig) (string, error) {
 	return string(yamlData), nil
 }
 
-// SaveTeapotYAML saves the teapot.yml file to the specified directory
-func SaveTeapotYAML(project models.ProjectConfig, outputDir string) error {
+// ParseTeapotYAML parses teapot.yml content into a project configuration.
+// Unknown keys are rejected so that typos in a committed config are reported instead of ignored.
+// Content written in an older version is migrated first.
+func ParseTeapotYAML(data []byte) (models.ProjectConfig, error) {
+	project, _, err := parseTeapotYAML(data)
+	return project, err
+}
+
+// parseTeapotYAML migrates and parses teapot.yml content, returning the
+// deprecation warnings of the migration.
+func parseTeapotYAML(data []byte) (models.ProjectConfig, []*errs.TeapotError, error) {
+	migration, err := MigrateTeapotYAML(data)
+	if err != nil {
+		return models.ProjectConfig{}, nil, err
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(migration.Content))
+	decoder.KnownFields(true)
+
+	var config TeapotConfig
+	if err := decoder.Decode(&config); err != nil {
+		if errors.Is(err, io.EOF) {
+			return models.ProjectConfig{}, nil, fmt.Errorf("teapot.yml is empty")
+		}
+		return models.ProjectConfig{}, nil, fmt.Errorf("failed to parse YAML: %w", err)
+	}
+
+	warnings := migration.Warnings
+	if migration.Migrated() {
+		warnings = append(warnings, errs.NewDeprecationError(
+			fmt.Sprintf("teapot.yml version %s is deprecated, run 'teapot migrate-config' to update it to %s", migration.From, teapotYAMLVersion), nil))
+	}
+	return config.toProjectConfig(), warnings, nil
+}
+
+// LoadTeapotYAML reads a teapot.yml file and converts it into a project configuration
+func LoadTeapotYAML(path string) (models.ProjectConfig, error) {
+	project, _, err := LoadTeapotYAMLWithWarnings(path)
+	return project, err
+}
+
+// LoadTeapotYAMLWithWarnings reads a teapot.yml file like LoadTeapotYAML and also
+// returns a deprecation warning for every setting migrated from an older version.
+func LoadTeapotYAMLWithWarnings(path string) (models.ProjectConfig, []*errs.TeapotError, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return models.ProjectConfig{}, nil, fmt.Errorf("failed to read YAML file: %w", err)
+	}
+
+	project, warnings, err := parseTeapotYAML(data)
+	if err != nil {
+		return models.ProjectConfig{}, nil, fmt.Errorf("%s: %w", path, err)
+	}
+
+	return project, warnings, nil
+}
+
+// ErrTeapotYAMLExists is returned by SaveTeapotYAML when it would replace an existing file
+var ErrTeapotYAMLExists = errors.New("teapot.yml already exists")
+
+// SaveTeapotYAML saves the teapot.yml file to the specified directory.
+// An existing teapot.yml is only replaced when overwrite is set.
+func SaveTeapotYAML(project models.ProjectConfig, outputDir string, overwrite bool) error {
 	yamlContent, err := GenerateTeapotYAML(project)
 	if err != nil {
 		return fmt.Errorf("failed to generate YAML: %w", err)
@@ -126,6 +272,9 @@ func SaveTeapotYAML(project models.ProjectConfig, outputDir string) error {
 
 	// Write YAML file
 	filePath := filepath.Join(outputDir, "teapot.yml")
+	if _, err := os.Stat(filePath); err == nil && !overwrite {
+		return fmt.Errorf("%w in %s", ErrTeapotYAMLExists, outputDir)
+	}
 	if err := os.WriteFile(filePath, []byte(yamlContent), 0644); err != nil {
 		return fmt.Errorf("failed to write YAML file: %w", err)
 	}
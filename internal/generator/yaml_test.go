@@ -0,0 +1,193 @@
+package generator
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"teapot/internal/models"
+)
+
+func TestLoadTeapotYAML_RoundTrip(t *testing.T) {
+	project := testProject()
+	project.Packages = []models.PackageType{models.PackageUI, models.PackageUtils}
+	project.AITools = models.AITools{Editor: "cursor", Extensions: []string{"prettier"}}
+	project.PackageManager = models.PackageManagerPnpm
+
+	content, err := GenerateTeapotYAML(project)
+	if err != nil {
+		t.Fatalf("Expected YAML to generate, got error: %v", err)
+	}
+
+	path := filepath.Join(t.TempDir(), "teapot.yml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("Failed to write teapot.yml: %v", err)
+	}
+
+	loaded, err := LoadTeapotYAML(path)
+	if err != nil {
+		t.Fatalf("Expected teapot.yml to load, got error: %v", err)
+	}
+	if !reflect.DeepEqual(loaded, project) {
+		t.Errorf("Expected loaded project %+v, got %+v", project, loaded)
+	}
+
+	regenerated, err := GenerateTeapotYAML(loaded)
+	if err != nil {
+		t.Fatalf("Expected YAML to regenerate, got error: %v", err)
+	}
+	if regenerated != content {
+		t.Errorf("Expected regenerated YAML to match:\n%s\ngot:\n%s", content, regenerated)
+	}
+}
+
+func TestParseTeapotYAML_EmptyLists(t *testing.T) {
+	project := models.ProjectConfig{Name: "bare", Architecture: models.ArchitectureTurborepo}
+
+	content, err := GenerateTeapotYAML(project)
+	if err != nil {
+		t.Fatalf("Expected YAML to generate, got error: %v", err)
+	}
+
+	loaded, err := ParseTeapotYAML([]byte(content))
+	if err != nil {
+		t.Fatalf("Expected YAML to parse, got error: %v", err)
+	}
+
+	regenerated, err := GenerateTeapotYAML(loaded)
+	if err != nil {
+		t.Fatalf("Expected YAML to regenerate, got error: %v", err)
+	}
+	if regenerated != content {
+		t.Errorf("Expected regenerated YAML to match:\n%s\ngot:\n%s", content, regenerated)
+	}
+}
+
+func TestParseTeapotYAML_EmptyPackages(t *testing.T) {
+	tests := []struct {
+		name     string
+		packages []models.PackageType
+		yaml     string
+	}{
+		{"no shared packages", []models.PackageType{}, "packages: []\n"},
+		{"unset", nil, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			project := testProject()
+			project.Packages = tt.packages
+
+			content, err := GenerateTeapotYAML(project)
+			if err != nil {
+				t.Fatalf("Expected YAML to generate, got error: %v", err)
+			}
+			if tt.yaml != "" && !strings.Contains(content, tt.yaml) {
+				t.Errorf("Expected YAML to contain %q, got:\n%s", tt.yaml, content)
+			}
+			if tt.yaml == "" && strings.Contains(content, "packages:") {
+				t.Errorf("Expected no packages key, got:\n%s", content)
+			}
+
+			loaded, err := ParseTeapotYAML([]byte(content))
+			if err != nil {
+				t.Fatalf("Expected YAML to parse, got error: %v", err)
+			}
+			if (loaded.Packages == nil) != (tt.packages == nil) || len(loaded.Packages) != 0 {
+				t.Errorf("Expected packages %#v, got %#v", tt.packages, loaded.Packages)
+			}
+
+			regenerated, err := GenerateTeapotYAML(loaded)
+			if err != nil {
+				t.Fatalf("Expected YAML to regenerate, got error: %v", err)
+			}
+			if regenerated != content {
+				t.Errorf("Expected regenerated YAML to match:\n%s\ngot:\n%s", content, regenerated)
+			}
+		})
+	}
+}
+
+func TestParseTeapotYAML_Errors(t *testing.T) {
+	valid, err := GenerateTeapotYAML(testProject())
+	if err != nil {
+		t.Fatalf("Expected YAML to generate, got error: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{
+			name:    "unknown top-level key",
+			content: valid + "extra: true\n",
+			wantErr: "field extra not found",
+		},
+		{
+			name:    "unknown nested key",
+			content: strings.Replace(valid, "    typescript: true", "    typescript: true\n    typscript: true", 1),
+			wantErr: "field typscript not found",
+		},
+		{
+			name:    "unsupported version",
+			content: strings.Replace(valid, `version: "`+teapotYAMLVersion+`"`, `version: "9.9"`, 1),
+			wantErr: "unsupported teapot.yml version",
+		},
+		{
+			name:    "empty document",
+			content: "",
+			wantErr: "empty",
+		},
+		{
+			name:    "malformed YAML",
+			content: "project: [",
+			wantErr: "failed to parse YAML",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseTeapotYAML([]byte(tt.content))
+			if err == nil {
+				t.Fatalf("Expected error containing %q, got nil", tt.wantErr)
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
+
+func TestLoadTeapotYAML_MissingFile(t *testing.T) {
+	_, err := LoadTeapotYAML(filepath.Join(t.TempDir(), "teapot.yml"))
+	if err == nil {
+		t.Error("Expected missing file to fail")
+	}
+}
+
+func TestSaveTeapotYAML_ExistingFile(t *testing.T) {
+	dir := t.TempDir()
+	if err := SaveTeapotYAML(testProject(), dir, false); err != nil {
+		t.Fatalf("Expected teapot.yml to save, got error: %v", err)
+	}
+
+	project := testProject()
+	project.Description = "Changed"
+	if err := SaveTeapotYAML(project, dir, false); !errors.Is(err, ErrTeapotYAMLExists) {
+		t.Errorf("Expected ErrTeapotYAMLExists, got %v", err)
+	}
+	if loaded, _ := LoadTeapotYAML(filepath.Join(dir, "teapot.yml")); loaded.Description == "Changed" {
+		t.Error("Expected the existing teapot.yml to be kept")
+	}
+
+	if err := SaveTeapotYAML(project, dir, true); err != nil {
+		t.Fatalf("Expected overwrite to succeed, got error: %v", err)
+	}
+	if loaded, _ := LoadTeapotYAML(filepath.Join(dir, "teapot.yml")); loaded.Description != "Changed" {
+		t.Error("Expected teapot.yml to be overwritten")
+	}
+}
@@ -20,6 +20,8 @@ const (
 	AppConfigScreen
 	// AddAnotherAppScreen asks if the user wants to add more applications
 	AddAnotherAppScreen
+	// PackagesScreen selects the shared workspace packages
+	PackagesScreen
 	// DevToolsScreen configures development tools like linting and TypeScript
 	DevToolsScreen
 	// InfrastructureScreen sets up infrastructure options like Docker and cloud providers
@@ -30,6 +32,8 @@ const (
 	AIToolsScreen
 	// YAMLPreviewScreen shows the generated teapot.yml configuration
 	YAMLPreviewScreen
+	// ConflictScreen asks how to handle a target directory that already has content
+	ConflictScreen
 	// GeneratingScreen shows the project generation progress
 	GeneratingScreen
 	// CompleteScreen displays completion message and next steps
@@ -45,10 +49,12 @@ var ScreenNames = map[Screen]string{
 	AddAppsScreen:        "Add Applications",
 	AppConfigScreen:      "App Configuration",
 	AddAnotherAppScreen:  "Add Another App",
+	PackagesScreen:       "Shared Packages",
 	DevToolsScreen:       "Development Tools",
 	InfrastructureScreen: "Infrastructure",
 	CIPipelineScreen:     "CI/CD Pipeline",
 	AIToolsScreen:        "AI Tools",
+	ConflictScreen:       "Existing Directory",
 	GeneratingScreen:     "Generating",
 	CompleteScreen:       "Complete",
 }
@@ -92,7 +98,7 @@ const (
 	ArchitectureTurborepo ArchitectureType = "turborepo"
 	// ArchitectureSingle represents a single application setup
 	ArchitectureSingle    ArchitectureType = "single"
-	// ArchitectureNx represents an Nx-based monorepo setup (coming soon)
+	// ArchitectureNx represents an Nx-based monorepo setup
 	ArchitectureNx        ArchitectureType = "nx"
 )
 
@@ -101,7 +107,67 @@ const (
 var ArchitectureNames = map[ArchitectureType]string{
 	ArchitectureTurborepo: "Turborepo (Recommended)",
 	ArchitectureSingle:    "Single Application",
-	ArchitectureNx:        "Nx (Coming Soon)",
+	ArchitectureNx:        "Nx",
+}
+
+// PackageManagerType represents the package manager that installs dependencies
+// and runs scripts in the generated project.
+type PackageManagerType string
+
+const (
+	// PackageManagerBun represents Bun, the default package manager
+	PackageManagerBun  PackageManagerType = "bun"
+	// PackageManagerPnpm represents pnpm
+	PackageManagerPnpm PackageManagerType = "pnpm"
+	// PackageManagerNpm represents npm, which ships with Node.js
+	PackageManagerNpm  PackageManagerType = "npm"
+	// PackageManagerYarn represents Yarn (Berry)
+	PackageManagerYarn PackageManagerType = "yarn"
+)
+
+// PackageManagerNames provides human-readable names for each package manager.
+// This is used in the UI for display purposes.
+var PackageManagerNames = map[PackageManagerType]string{
+	PackageManagerBun:  "Bun",
+	PackageManagerPnpm: "pnpm",
+	PackageManagerNpm:  "npm",
+	PackageManagerYarn: "Yarn",
+}
+
+// PackageType represents the shared internal packages that can be generated
+// in the packages/ folder of a monorepo project.
+type PackageType string
+
+const (
+	// PackageUI represents a shared React component library
+	PackageUI             PackageType = "ui"
+	// PackageTSConfig represents shared TypeScript configurations
+	PackageTSConfig       PackageType = "tsconfig"
+	// PackageESLintConfig represents a shared ESLint configuration
+	PackageESLintConfig   PackageType = "eslint-config"
+	// PackagePrettierConfig represents a shared Prettier configuration
+	PackagePrettierConfig PackageType = "prettier-config"
+	// PackageDatabase represents shared database models and client
+	PackageDatabase       PackageType = "database"
+	// PackageAuth represents shared authentication helpers
+	PackageAuth           PackageType = "auth"
+	// PackageUtils represents shared utility functions
+	PackageUtils          PackageType = "utils"
+	// PackageAPIClient represents a typed client for the project's APIs
+	PackageAPIClient      PackageType = "api-client"
+)
+
+// PackageTypeNames provides human-readable names for each package type.
+// This is used in the UI for display purposes.
+var PackageTypeNames = map[PackageType]string{
+	PackageUI:             "UI Components Library",
+	PackageTSConfig:       "TypeScript Config",
+	PackageESLintConfig:   "ESLint Config",
+	PackagePrettierConfig: "Prettier Config",
+	PackageDatabase:       "Database Models",
+	PackageAuth:           "Auth Package",
+	PackageUtils:          "Utils/Helpers",
+	PackageAPIClient:      "API Client",
 }
 
 // Application represents a single application within the monorepo project.
@@ -175,8 +241,12 @@ type ProjectConfig struct {
 	Description    string
 	// Architecture specifies the monorepo architecture to use
 	Architecture   ArchitectureType
+	// PackageManager installs dependencies and runs scripts; empty means Bun
+	PackageManager PackageManagerType
 	// Applications contains all applications to be created in the project
 	Applications   []Application
+	// Packages lists the shared packages to be created in the packages/ folder
+	Packages       []PackageType
 	// DevTools contains development tools configuration
 	DevTools       DevTools
 	// Infrastructure contains infrastructure and deployment configuration
@@ -200,4 +270,7 @@ type AppState struct {
 	CurrentAppIndex  int
 	// Quitting indicates whether the application is in the process of quitting
 	Quitting         bool
+	// Prefilled marks the screens answered before the wizard started (e.g. by command line flags);
+	// forward navigation skips them while their answers are complete
+	Prefilled        map[Screen]bool
 }
\ No newline at end of file
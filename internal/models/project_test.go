@@ -15,6 +15,7 @@ func TestScreenNames(t *testing.T) {
 		{AddAppsScreen, "Add Applications"},
 		{AppConfigScreen, "App Configuration"},
 		{AddAnotherAppScreen, "Add Another App"},
+		{PackagesScreen, "Shared Packages"},
 		{DevToolsScreen, "Development Tools"},
 		{InfrastructureScreen, "Infrastructure"},
 		{CIPipelineScreen, "CI/CD Pipeline"},
@@ -65,7 +66,7 @@ func TestArchitectureNames(t *testing.T) {
 	}{
 		{ArchitectureTurborepo, "Turborepo (Recommended)"},
 		{ArchitectureSingle, "Single Application"},
-		{ArchitectureNx, "Nx (Coming Soon)"},
+		{ArchitectureNx, "Nx"},
 	}
 
 	for _, tt := range tests {
@@ -79,6 +80,32 @@ func TestArchitectureNames(t *testing.T) {
 	}
 }
 
+func TestPackageTypeNames(t *testing.T) {
+	tests := []struct {
+		packageType PackageType
+		expected    string
+	}{
+		{PackageUI, "UI Components Library"},
+		{PackageTSConfig, "TypeScript Config"},
+		{PackageESLintConfig, "ESLint Config"},
+		{PackagePrettierConfig, "Prettier Config"},
+		{PackageDatabase, "Database Models"},
+		{PackageAuth, "Auth Package"},
+		{PackageUtils, "Utils/Helpers"},
+		{PackageAPIClient, "API Client"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.expected, func(t *testing.T) {
+			if name, exists := PackageTypeNames[tt.packageType]; !exists {
+				t.Errorf("PackageType %v not found in PackageTypeNames map", tt.packageType)
+			} else if name != tt.expected {
+				t.Errorf("Expected package type name '%s', but got '%s'", tt.expected, name)
+			}
+		})
+	}
+}
+
 func TestAppStateInitialization(t *testing.T) {
 	state := AppState{
 		CurrentScreen: WelcomeScreen,
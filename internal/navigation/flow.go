@@ -4,6 +4,7 @@
 package navigation
 
 import (
+	"teapot/internal/generator"
 	"teapot/internal/models"
 	"teapot/internal/ui/screens"
 )
@@ -29,10 +30,11 @@ func NewNavigationFlow() *NavigationFlow {
 	nf.transitions[models.ProjectSetupScreen] = models.WelcomeScreen
 	nf.transitions[models.ArchitectureScreen] = models.ProjectSetupScreen
 	nf.transitions[models.AppConfigScreen] = models.AddAppsScreen
-	nf.transitions[models.DevToolsScreen] = models.AddAnotherAppScreen
+	nf.transitions[models.PackagesScreen] = models.AddAnotherAppScreen
 	nf.transitions[models.InfrastructureScreen] = models.DevToolsScreen
 	nf.transitions[models.CIPipelineScreen] = models.InfrastructureScreen
 	nf.transitions[models.AIToolsScreen] = models.CIPipelineScreen
+	nf.transitions[models.ConflictScreen] = models.YAMLPreviewScreen
 	
 	// Define conditional navigation logic
 	nf.conditionalTransitions[models.AddAppsScreen] = func(state *models.AppState) models.Screen {
@@ -44,6 +46,14 @@ func NewNavigationFlow() *NavigationFlow {
 		return models.ArchitectureScreen
 	}
 	
+	nf.conditionalTransitions[models.DevToolsScreen] = func(state *models.AppState) models.Screen {
+		if state.Project.Architecture == models.ArchitectureSingle {
+			// Single applications skip the shared packages screen
+			return models.AddAnotherAppScreen
+		}
+		return models.PackagesScreen
+	}
+	
 	nf.conditionalTransitions[models.AddAnotherAppScreen] = func(state *models.AppState) models.Screen {
 		// This is handled specially in the main navigation logic
 		// as it involves removing the last app and going back to its config
@@ -113,6 +123,83 @@ func (nf *NavigationFlow) NavigateBack(current models.Screen, state *models.AppS
 	return state, previousScreen, nil
 }
 
+// forwardScreen returns the screen that follows a completed screen in the wizard.
+// Adding and configuring applications is treated as one answer.
+func (nf *NavigationFlow) forwardScreen(current models.Screen, state *models.AppState) models.Screen {
+	switch current {
+	case models.WelcomeScreen:
+		return models.ProjectSetupScreen
+	case models.ProjectSetupScreen:
+		return models.ArchitectureScreen
+	case models.ArchitectureScreen:
+		return models.AddAppsScreen
+	case models.AddAppsScreen, models.AppConfigScreen, models.AddAnotherAppScreen:
+		if state.Project.Architecture == models.ArchitectureSingle {
+			// Single applications skip the shared packages screen
+			return models.DevToolsScreen
+		}
+		return models.PackagesScreen
+	case models.PackagesScreen:
+		return models.DevToolsScreen
+	case models.DevToolsScreen:
+		return models.InfrastructureScreen
+	case models.InfrastructureScreen:
+		return models.CIPipelineScreen
+	case models.CIPipelineScreen:
+		return models.AIToolsScreen
+	case models.AIToolsScreen:
+		return models.YAMLPreviewScreen
+	default:
+		return current
+	}
+}
+
+// isAnswered reports whether a project already holds the answers a screen asks for.
+func isAnswered(screen models.Screen, project models.ProjectConfig) bool {
+	switch screen {
+	case models.WelcomeScreen, models.ProjectSetupScreen:
+		return project.Name != ""
+	case models.ArchitectureScreen:
+		return project.Architecture != ""
+	case models.AddAppsScreen, models.AppConfigScreen, models.AddAnotherAppScreen:
+		return len(project.Applications) > 0
+	case models.DevToolsScreen:
+		return project.DevTools.Linting != ""
+	case models.CIPipelineScreen:
+		return project.CIPipeline.Provider != ""
+	case models.AIToolsScreen:
+		return project.AITools.Editor != ""
+	default:
+		return false
+	}
+}
+
+// PrefilledScreens returns the screens whose answers are already complete in a project
+// seeded before the wizard starts.
+func (nf *NavigationFlow) PrefilledScreens(project models.ProjectConfig) map[models.Screen]bool {
+	prefilled := make(map[models.Screen]bool)
+	for screen := range models.ScreenNames {
+		if isAnswered(screen, project) {
+			prefilled[screen] = true
+		}
+	}
+	return prefilled
+}
+
+// SkipAnswered returns the first screen, starting at target, that still needs input.
+// Only prefilled screens are skipped, and only while their answers are complete, so
+// answers removed through back navigation (such as applications) are asked again.
+func (nf *NavigationFlow) SkipAnswered(target models.Screen, state *models.AppState) models.Screen {
+	for state.Prefilled[target] && isAnswered(target, state.Project) {
+		next := nf.forwardScreen(target, state)
+		if next == target {
+			break
+		}
+		target = next
+	}
+	return target
+}
+
 // GetScreenFactory returns a factory function for creating new screen models.
 // This centralizes screen creation logic and ensures consistent initialization.
 func (nf *NavigationFlow) GetScreenFactory(screen models.Screen) func(...interface{}) interface{} {
@@ -150,6 +237,8 @@ func (nf *NavigationFlow) GetScreenFactory(screen models.Screen) func(...interfa
 			}
 			return screens.NewAddAnotherAppModel(appCount, architecture)
 		}
+	case models.PackagesScreen:
+		return func(...interface{}) interface{} { return screens.NewPackagesModel() }
 	case models.DevToolsScreen:
 		return func(...interface{}) interface{} { return screens.NewDevToolsModel() }
 	case models.InfrastructureScreen:
@@ -158,17 +247,63 @@ func (nf *NavigationFlow) GetScreenFactory(screen models.Screen) func(...interfa
 		return func(...interface{}) interface{} { return screens.NewCIPipelineModel() }
 	case models.AIToolsScreen:
 		return func(...interface{}) interface{} { return screens.NewAIToolsModel() }
+	case models.YAMLPreviewScreen:
+		return func(args ...interface{}) interface{} {
+			project := models.ProjectConfig{}
+			if len(args) > 0 {
+				if config, ok := args[0].(models.ProjectConfig); ok {
+					project = config
+				}
+			}
+			return screens.NewYAMLPreviewModel(project)
+		}
+	case models.ConflictScreen:
+		return func(args ...interface{}) interface{} {
+			targetDir := ""
+			state := generator.TargetNonEmpty
+			if len(args) > 0 {
+				if dir, ok := args[0].(string); ok {
+					targetDir = dir
+				}
+			}
+			if len(args) > 1 {
+				if targetState, ok := args[1].(generator.TargetState); ok {
+					state = targetState
+				}
+			}
+			return screens.NewConflictModel(targetDir, state)
+		}
 	case models.GeneratingScreen:
-		return func(...interface{}) interface{} { return screens.NewGeneratingModel() }
+		return func(args ...interface{}) interface{} {
+			project := models.ProjectConfig{}
+			policy := generator.ConflictAbort
+			if len(args) > 0 {
+				if config, ok := args[0].(models.ProjectConfig); ok {
+					project = config
+				}
+			}
+			if len(args) > 1 {
+				if conflictPolicy, ok := args[1].(generator.ConflictPolicy); ok {
+					policy = conflictPolicy
+				}
+			}
+			return screens.NewGeneratingModel(project, project.Name, policy)
+		}
 	case models.CompleteScreen:
 		return func(args ...interface{}) interface{} {
 			projectName := "project"
+			packageManager := models.PackageManagerBun
 			if len(args) > 0 {
 				if name, ok := args[0].(string); ok {
 					projectName = name
 				}
 			}
-			return screens.NewCompleteModel(projectName)
+			if len(args) > 1 {
+				if pm, ok := args[1].(models.PackageManagerType); ok {
+					packageManager = pm
+				}
+			}
+			return screens.NewCompleteModel(projectName, packageManager)
 		}
 	default:
 		return func(...interface{}) interface{} { return screens.NewWelcomeModel() }
@@ -204,6 +339,10 @@ func (nf *NavigationFlow) GetNextScreen(current models.Screen, msgType string) m
 			// This is handled in the main logic with the action parameter
 			return models.AddAppsScreen // Default, but will be overridden
 		}
+	case models.PackagesScreen:
+		if msgType == "PackagesSelected" {
+			return models.DevToolsScreen
+		}
 	case models.DevToolsScreen:
 		if msgType == "DevToolsSelected" {
 			return models.InfrastructureScreen
@@ -17,7 +17,7 @@ func TestNavigationFlow_BasicTransitions(t *testing.T) {
 		{models.ProjectSetupScreen, models.WelcomeScreen},
 		{models.ArchitectureScreen, models.ProjectSetupScreen},
 		{models.AppConfigScreen, models.AddAppsScreen},
-		{models.DevToolsScreen, models.AddAnotherAppScreen},
+		{models.PackagesScreen, models.AddAnotherAppScreen},
 		{models.InfrastructureScreen, models.DevToolsScreen},
 		{models.CIPipelineScreen, models.InfrastructureScreen},
 		{models.AIToolsScreen, models.CIPipelineScreen},
@@ -53,6 +53,20 @@ func TestNavigationFlow_ConditionalTransitions(t *testing.T) {
 	if result != models.AddAnotherAppScreen {
 		t.Errorf("Expected AddAppsScreen with apps to go to AddAnotherAppScreen, got %v", result)
 	}
+	
+	// Test DevToolsScreen in a monorepo goes back to shared packages
+	state.Project.Architecture = models.ArchitectureTurborepo
+	result = nf.GetPreviousScreen(models.DevToolsScreen, state)
+	if result != models.PackagesScreen {
+		t.Errorf("Expected DevToolsScreen in a monorepo to go to PackagesScreen, got %v", result)
+	}
+	
+	// Test DevToolsScreen for a single application skips shared packages
+	state.Project.Architecture = models.ArchitectureSingle
+	result = nf.GetPreviousScreen(models.DevToolsScreen, state)
+	if result != models.AddAnotherAppScreen {
+		t.Errorf("Expected DevToolsScreen for a single app to go to AddAnotherAppScreen, got %v", result)
+	}
 }
 
 func TestNavigationFlow_CanNavigateBack(t *testing.T) {
@@ -65,6 +79,7 @@ func TestNavigationFlow_CanNavigateBack(t *testing.T) {
 		models.AddAppsScreen,
 		models.AppConfigScreen,
 		models.AddAnotherAppScreen,
+		models.PackagesScreen,
 		models.DevToolsScreen,
 		models.InfrastructureScreen,
 		models.CIPipelineScreen,
@@ -184,6 +199,7 @@ func TestNavigationFlow_GetScreenFactory(t *testing.T) {
 		models.ProjectSetupScreen,
 		models.ArchitectureScreen,
 		models.AddAppsScreen,
+		models.PackagesScreen,
 		models.DevToolsScreen,
 		models.InfrastructureScreen,
 		models.CIPipelineScreen,
@@ -243,6 +259,7 @@ func TestNavigationFlow_GetNextScreen(t *testing.T) {
 		{models.ArchitectureScreen, "ArchitectureSelected", models.AddAppsScreen},
 		{models.AddAppsScreen, "AppTypeSelected", models.AppConfigScreen},
 		{models.AppConfigScreen, "AppConfigComplete", models.AddAnotherAppScreen},
+		{models.PackagesScreen, "PackagesSelected", models.DevToolsScreen},
 		{models.DevToolsScreen, "DevToolsSelected", models.InfrastructureScreen},
 		{models.InfrastructureScreen, "InfrastructureSelected", models.CIPipelineScreen},
 		{models.CIPipelineScreen, "CIPipelineSelected", models.AIToolsScreen},
@@ -299,4 +316,74 @@ func TestNavigationFlow_RestrictedBackNavigation(t *testing.T) {
 			t.Errorf("Expected no screen model for %v", screen)
 		}
 	}
-}
\ No newline at end of file
+}
+func TestNavigationFlow_PrefilledScreens(t *testing.T) {
+	nf := NewNavigationFlow()
+
+	project := models.ProjectConfig{
+		Name:         "demo",
+		Architecture: models.ArchitectureTurborepo,
+		DevTools:     models.DevTools{Linting: "biome"},
+	}
+	prefilled := nf.PrefilledScreens(project)
+
+	for _, screen := range []models.Screen{models.WelcomeScreen, models.ProjectSetupScreen, models.ArchitectureScreen, models.DevToolsScreen} {
+		if !prefilled[screen] {
+			t.Errorf("Expected %v to be prefilled", screen)
+		}
+	}
+	for _, screen := range []models.Screen{models.AddAppsScreen, models.PackagesScreen, models.InfrastructureScreen, models.CIPipelineScreen, models.AIToolsScreen} {
+		if prefilled[screen] {
+			t.Errorf("Expected %v not to be prefilled", screen)
+		}
+	}
+}
+
+func TestNavigationFlow_SkipAnswered(t *testing.T) {
+	nf := NewNavigationFlow()
+
+	project := models.ProjectConfig{
+		Name:         "demo",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{{Name: "web", Type: models.AppTypeNext}},
+		DevTools:     models.DevTools{Linting: "biome"},
+		CIPipeline:   models.CIPipeline{Provider: "github"},
+		AITools:      models.AITools{Editor: "cursor"},
+	}
+	state := &models.AppState{Project: project, Prefilled: nf.PrefilledScreens(project)}
+
+	tests := []struct {
+		target   models.Screen
+		expected models.Screen
+	}{
+		{models.WelcomeScreen, models.PackagesScreen},
+		{models.PackagesScreen, models.PackagesScreen},
+		{models.DevToolsScreen, models.InfrastructureScreen},
+		{models.CIPipelineScreen, models.YAMLPreviewScreen},
+	}
+
+	for _, tt := range tests {
+		result := nf.SkipAnswered(tt.target, state)
+		if result != tt.expected {
+			t.Errorf("Expected %v to skip to %v, got %v", tt.target, tt.expected, result)
+		}
+	}
+
+	// Single applications skip the packages screen as well
+	state.Project.Architecture = models.ArchitectureSingle
+	if result := nf.SkipAnswered(models.AddAppsScreen, state); result != models.InfrastructureScreen {
+		t.Errorf("Expected single application to skip to InfrastructureScreen, got %v", result)
+	}
+
+	// Removing the prefilled applications asks for them again
+	state.Project.Applications = nil
+	if result := nf.SkipAnswered(models.AddAppsScreen, state); result != models.AddAppsScreen {
+		t.Errorf("Expected AddAppsScreen once applications are removed, got %v", result)
+	}
+
+	// Screens answered in the wizard itself are never skipped
+	state = &models.AppState{Project: project}
+	if result := nf.SkipAnswered(models.DevToolsScreen, state); result != models.DevToolsScreen {
+		t.Errorf("Expected DevToolsScreen without prefilled answers, got %v", result)
+	}
+}
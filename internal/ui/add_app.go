@@ -0,0 +1,143 @@
+package ui
+
+import (
+	"fmt"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+	"teapot/internal/ui/components"
+	"teapot/internal/ui/screens"
+	"teapot/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// AddAppModel runs only the application screens of the wizard to add one
+// application to an existing project.
+type AddAppModel struct {
+	// project is the existing project the application is added to
+	project models.ProjectConfig
+	// screen is either AddAppsScreen or AppConfigScreen
+	screen models.Screen
+	// screenModels holds the models of both screens
+	screenModels map[models.Screen]tea.Model
+	// appType is the type chosen on the AddAppsScreen
+	appType models.AppType
+	// app is the configured application once the flow has finished
+	app *models.Application
+	// err explains why the configured application cannot be added
+	err error
+	// windowWidth stores the current terminal width
+	windowWidth int
+	// windowHeight stores the current terminal height
+	windowHeight int
+}
+
+// NewAddAppModel creates the add-application flow for an existing project.
+func NewAddAppModel(project models.ProjectConfig) AddAppModel {
+	return AddAppModel{
+		project: project,
+		screen:  models.AddAppsScreen,
+		screenModels: map[models.Screen]tea.Model{
+			models.AddAppsScreen: screens.NewAddAppsModel(),
+		},
+	}
+}
+
+// Application returns the configured application, or false if the flow was cancelled.
+func (m AddAppModel) Application() (models.Application, bool) {
+	if m.app == nil {
+		return models.Application{}, false
+	}
+	return *m.app, true
+}
+
+func (m AddAppModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m AddAppModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.windowWidth = msg.Width
+		m.windowHeight = msg.Height
+		return m, nil
+
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			return m, tea.Quit
+		}
+		m.err = nil
+		updatedModel, cmd := m.screenModels[m.screen].Update(msg)
+		m.screenModels[m.screen] = updatedModel
+		return m, cmd
+
+	case screens.AppTypeSelectedMsg:
+		if m.screen == models.AddAppsScreen {
+			m.appType = msg.AppType
+			m.screen = models.AppConfigScreen
+			m.screenModels[models.AppConfigScreen] = screens.NewAppConfigModel(msg.AppType)
+		}
+		return m, nil
+
+	case screens.AppConfigCompleteMsg:
+		if m.screen == models.AppConfigScreen {
+			app := models.Application{
+				ID:      generator.NewApplicationID(m.project.Applications, m.appType),
+				Name:    msg.AppName,
+				Type:    m.appType,
+				Options: msg.Options,
+			}
+
+			// Check the result before leaving so a clashing name can be changed here
+			project := m.project
+			project.Applications = append(append([]models.Application{}, project.Applications...), app)
+			if _, err := generator.BuildPlan(project); err != nil {
+				m.err = err
+				return m, nil
+			}
+
+			m.app = &app
+			return m, tea.Quit
+		}
+		return m, nil
+	}
+
+	return m, nil
+}
+
+func (m AddAppModel) View() string {
+	content := components.RenderTitle() + "\n\n"
+	content += lipgloss.NewStyle().
+		Foreground(styles.ColorTextMuted).
+		Render(fmt.Sprintf("Adding an application to %s", m.project.Name)) + "\n\n"
+	content += m.screenModels[m.screen].View()
+
+	if m.err != nil {
+		content += "\n" + lipgloss.NewStyle().
+			Foreground(styles.ColorError).
+			Render("⚠️  "+m.err.Error())
+	}
+
+	help := "↑↓: navigate • enter: select • esc: cancel"
+	if m.screen == models.AppConfigScreen {
+		help = "↑↓: navigate • space: toggle • tab: switch fields • enter: continue • esc: cancel"
+	}
+	content += "\n\n" + components.RenderHelp(help)
+
+	if m.windowWidth == 0 || m.windowHeight == 0 {
+		return content
+	}
+
+	leftPanel := styles.GetLeftPanelStyle(m.windowWidth, m.windowHeight).Render(content)
+	rightPanel := components.RenderProjectStructure(m.project, m.windowWidth, m.windowHeight)
+	return lipgloss.Place(
+		m.windowWidth,
+		m.windowHeight,
+		lipgloss.Center,
+		lipgloss.Center,
+		lipgloss.JoinHorizontal(lipgloss.Top, leftPanel, rightPanel),
+	)
+}
@@ -66,6 +66,27 @@ func NewModel() Model {
 	}
 }
 
+// NewModelWithProject creates a main application model prefilled with answers given on
+// the command line. Screens whose answers are already known are skipped, but back
+// navigation still reaches them so the answers can be edited.
+func NewModelWithProject(project models.ProjectConfig) Model {
+	m := NewModel()
+	m.state.Project = project
+	m.state.Prefilled = m.navigationFlow.PrefilledScreens(project)
+
+	if project.Name != "" {
+		// A prefilled name skips the project setup screen, so its package manager
+		// keeps the preselected answer unless one was given
+		if project.PackageManager == "" {
+			m.state.Project.PackageManager = screens.Defaults().PackageManager
+		}
+		m.screenModels[models.ProjectSetupScreen] = screens.NewPrefilledProjectSetupModel(project.Name, project.Description, m.state.Project.PackageManager)
+	}
+	m.advanceTo(models.WelcomeScreen)
+
+	return m
+}
+
 func (m Model) Init() tea.Cmd {
 	return nil
 }
@@ -135,7 +156,11 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		// Handle global quit keys first before passing to individual screens
 		switch msg.String() {
 		case "ctrl+c":
-			// Allow Ctrl+C to quit from any screen
+			// The generating screen stops the engine before quitting
+			if m.state.CurrentScreen == models.GeneratingScreen {
+				break
+			}
+			// Allow Ctrl+C to quit from any other screen
 			m.state.Quitting = true
 			return m, tea.Quit
 		case "esc":
@@ -174,10 +199,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 
 	case screens.WelcomeCompleteMsg:
 		if m.state.CurrentScreen == models.WelcomeScreen {
-			m.state.CurrentScreen = models.ProjectSetupScreen
-			if _, exists := m.screenModels[models.ProjectSetupScreen]; !exists {
-				m.addScreenModel(models.ProjectSetupScreen, screens.NewProjectSetupModel())
-			}
+			m.advanceTo(models.ProjectSetupScreen)
 		}
 		return m, nil
 
@@ -185,20 +207,15 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.state.CurrentScreen == models.ProjectSetupScreen {
 			m.state.Project.Name = msg.ProjectName
 			m.state.Project.Description = msg.Description
-			m.state.CurrentScreen = models.ArchitectureScreen
-			if _, exists := m.screenModels[models.ArchitectureScreen]; !exists {
-				m.addScreenModel(models.ArchitectureScreen, screens.NewArchitectureModel())
-			}
+			m.state.Project.PackageManager = msg.PackageManager
+			m.advanceTo(models.ArchitectureScreen)
 		}
 		return m, nil
 
 	case screens.ArchitectureSelectedMsg:
 		if m.state.CurrentScreen == models.ArchitectureScreen {
 			m.state.Project.Architecture = msg.Architecture
-			m.state.CurrentScreen = models.AddAppsScreen
-			if _, exists := m.screenModels[models.AddAppsScreen]; !exists {
-				m.addScreenModel(models.AddAppsScreen, screens.NewAddAppsModel())
-			}
+			m.advanceTo(models.AddAppsScreen)
 		}
 		return m, nil
 
@@ -206,7 +223,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		if m.state.CurrentScreen == models.AddAppsScreen {
 			// Create new application and start configuration
 			newApp := models.Application{
-				ID:      "app-" + string(msg.AppType),
+				ID:      generator.NewApplicationID(m.state.Project.Applications, msg.AppType),
 				Name:    string(msg.AppType),
 				Type:    msg.AppType,
 				Options: make(map[string]interface{}),
@@ -244,16 +261,24 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.state.CurrentScreen = models.AddAppsScreen
 				// Refresh the screen model for new app selection
 				m.screenModels[models.AddAppsScreen] = screens.NewAddAppsModel()
+			} else if m.state.Project.Architecture == models.ArchitectureSingle {
+				// Single applications have no workspace packages, continue to dev tools
+				m.advanceTo(models.DevToolsScreen)
 			} else {
-				// Continue to dev tools
-				m.state.CurrentScreen = models.DevToolsScreen
-				if _, exists := m.screenModels[models.DevToolsScreen]; !exists {
-					m.screenModels[models.DevToolsScreen] = screens.NewDevToolsModel()
-				}
+				// Continue to shared packages
+				m.advanceTo(models.PackagesScreen)
 			}
 		}
 		return m, nil
 
+	case screens.PackagesSelectedMsg:
+		if m.state.CurrentScreen == models.PackagesScreen {
+			m.state.Project.Packages = msg.Packages
+			
+			m.advanceTo(models.DevToolsScreen)
+		}
+		return m, nil
+
 	case screens.DevToolsSelectedMsg:
 		if m.state.CurrentScreen == models.DevToolsScreen {
 			m.state.Project.DevTools.Linting = msg.LintingTool
@@ -261,10 +286,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.Project.DevTools.Husky = true
 			m.state.Project.DevTools.LintStaged = true
 			
-			m.state.CurrentScreen = models.InfrastructureScreen
-			if _, exists := m.screenModels[models.InfrastructureScreen]; !exists {
-				m.screenModels[models.InfrastructureScreen] = screens.NewInfrastructureModel()
-			}
+			m.advanceTo(models.InfrastructureScreen)
 		}
 		return m, nil
 
@@ -275,10 +297,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.Project.Infrastructure.Pulumi = msg.Options["pulumi"]
 			m.state.Project.Infrastructure.Terraform = msg.Options["terraform"]
 			
-			m.state.CurrentScreen = models.CIPipelineScreen
-			if _, exists := m.screenModels[models.CIPipelineScreen]; !exists {
-				m.screenModels[models.CIPipelineScreen] = screens.NewCIPipelineModel()
-			}
+			m.advanceTo(models.CIPipelineScreen)
 		}
 		return m, nil
 
@@ -287,10 +306,7 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.Project.CIPipeline.Provider = msg.Provider
 			m.state.Project.CIPipeline.Features = msg.Features
 			
-			m.state.CurrentScreen = models.AIToolsScreen
-			if _, exists := m.screenModels[models.AIToolsScreen]; !exists {
-				m.screenModels[models.AIToolsScreen] = screens.NewAIToolsModel()
-			}
+			m.advanceTo(models.AIToolsScreen)
 		}
 		return m, nil
 
@@ -300,32 +316,43 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			m.state.Project.AITools.Extensions = msg.Extensions
 			
 			// Move to YAML preview
-			m.state.CurrentScreen = models.YAMLPreviewScreen
-			if _, exists := m.screenModels[models.YAMLPreviewScreen]; !exists {
-				m.screenModels[models.YAMLPreviewScreen] = screens.NewYAMLPreviewModel(m.state.Project)
-			}
+			m.advanceTo(models.YAMLPreviewScreen)
 		}
 		return m, nil
 
 	case screens.YAMLSaveMsg:
 		if m.state.CurrentScreen == models.YAMLPreviewScreen {
-			// Save teapot.yml to current directory
-			err := generator.SaveTeapotYAML(msg.Project, ".")
+			// Save teapot.yml to current directory; an existing file needs confirmation
+			err := generator.SaveTeapotYAML(m.state.Project, ".", msg.Overwrite)
+			return m.updateScreen(models.YAMLPreviewScreen, screens.YAMLSavedMsg{Err: err})
+		}
+		return m, nil
+
+	case screens.YAMLContinueMsg:
+		if m.state.CurrentScreen == models.YAMLPreviewScreen {
+			// Generation goes into ./<project name>; ask first if it already has content
+			state, err := generator.InspectTarget(m.state.Project.Name)
 			if err != nil {
-				// Handle error (could show error message)
+				m.errorDisplay.ShowError(errors.NewSystemError(err.Error(), err))
+				return m, nil
+			}
+			if state.HasContent() {
+				m.state.CurrentScreen = models.ConflictScreen
+				m.addScreenModel(models.ConflictScreen, screens.NewConflictModel(m.state.Project.Name, state))
 				return m, nil
 			}
-			// Could show success message
+			return m.startGeneration(generator.ConflictAbort)
 		}
 		return m, nil
 
-	case screens.YAMLContinueMsg:
-		if m.state.CurrentScreen == models.YAMLPreviewScreen {
-			// Continue to generation
-			m.state.CurrentScreen = models.GeneratingScreen
-			if _, exists := m.screenModels[models.GeneratingScreen]; !exists {
-				m.screenModels[models.GeneratingScreen] = screens.NewGeneratingModel()
+	case screens.ConflictResolvedMsg:
+		if m.state.CurrentScreen == models.ConflictScreen {
+			if msg.Policy == generator.ConflictAbort {
+				// Leave the directory untouched and return to the preview
+				m.state.CurrentScreen = models.YAMLPreviewScreen
+				return m, nil
 			}
+			return m.startGeneration(msg.Policy)
 		}
 		return m, nil
 
@@ -336,11 +363,20 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 		}
 		return m, nil
 
+	case screens.GenerationEventMsg, screens.GenerationFinishedMsg, screens.InstallOutputMsg, screens.InstallFinishedMsg:
+		// Forward engine and install progress to the generating screen
+		if screenModel, exists := m.screenModels[models.GeneratingScreen]; exists {
+			updatedModel, cmd := screenModel.Update(msg)
+			m.screenModels[models.GeneratingScreen] = updatedModel
+			return m, cmd
+		}
+		return m, nil
+
 	case screens.GenerationCompleteMsg:
 		if m.state.CurrentScreen == models.GeneratingScreen {
 			m.state.CurrentScreen = models.CompleteScreen
 			if _, exists := m.screenModels[models.CompleteScreen]; !exists {
-				m.screenModels[models.CompleteScreen] = screens.NewCompleteModel(m.state.Project.Name)
+				m.screenModels[models.CompleteScreen] = screens.NewCompleteModel(m.state.Project.Name, m.state.Project.PackageManager)
 			}
 		}
 		return m, nil
@@ -349,6 +385,54 @@ func (m Model) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	return m, nil
 }
 
+// startGeneration switches to the generating screen and starts the engine
+func (m Model) startGeneration(policy generator.ConflictPolicy) (Model, tea.Cmd) {
+	m.state.CurrentScreen = models.GeneratingScreen
+	generating := screens.NewGeneratingModel(m.state.Project, m.state.Project.Name, policy)
+	m.addScreenModel(models.GeneratingScreen, generating)
+	return m, m.screenModels[models.GeneratingScreen].Init()
+}
+
+// updateScreen forwards a message to a screen model that is not driven by key presses
+func (m Model) updateScreen(screen models.Screen, msg tea.Msg) (Model, tea.Cmd) {
+	if screenModel, exists := m.screenModels[screen]; exists {
+		updatedModel, cmd := screenModel.Update(msg)
+		m.screenModels[screen] = updatedModel
+		return m, cmd
+	}
+	return m, nil
+}
+
+// advanceTo moves forward to a screen, skipping screens whose answers were prefilled.
+// The YAML preview is rebuilt every time, since the answers may have changed
+// after going back from it.
+func (m *Model) advanceTo(screen models.Screen) {
+	screen = m.navigationFlow.SkipAnswered(screen, &m.state)
+	m.state.CurrentScreen = screen
+	if _, exists := m.screenModels[screen]; !exists || screen == models.YAMLPreviewScreen {
+		m.addScreenModel(screen, m.newScreenModel(screen))
+	}
+}
+
+// newScreenModel creates a screen model using the navigation flow factory
+func (m *Model) newScreenModel(screen models.Screen) tea.Model {
+	factory := m.navigationFlow.GetScreenFactory(screen)
+
+	var screenModel interface{}
+	switch screen {
+	case models.AddAnotherAppScreen:
+		// AddAnotherAppScreen needs app count and architecture
+		screenModel = factory(len(m.state.Project.Applications), m.state.Project.Architecture)
+	case models.YAMLPreviewScreen:
+		screenModel = factory(m.state.Project)
+	default:
+		screenModel = factory()
+	}
+
+	teaModel, _ := screenModel.(tea.Model)
+	return teaModel
+}
+
 // navigateBack handles back navigation using the navigation state machine
 func (m Model) navigateBack() (Model, tea.Cmd) {
 	// Use the navigation flow to handle back navigation
@@ -363,24 +447,9 @@ func (m Model) navigateBack() (Model, tea.Cmd) {
 		if teaModel, ok := screenModel.(tea.Model); ok {
 			m.addScreenModel(newScreen, teaModel)
 		}
-	} else {
-		// Ensure the target screen model exists
-		if _, exists := m.screenModels[newScreen]; !exists {
-			// Create the screen model using the navigation flow factory
-			factory := m.navigationFlow.GetScreenFactory(newScreen)
-			if newScreen == models.AddAnotherAppScreen {
-				// Special case: AddAnotherAppScreen needs app count and architecture
-				screenModel := factory(len(m.state.Project.Applications), m.state.Project.Architecture)
-				if teaModel, ok := screenModel.(tea.Model); ok {
-					m.addScreenModel(newScreen, teaModel)
-				}
-			} else {
-				screenModel := factory()
-				if teaModel, ok := screenModel.(tea.Model); ok {
-					m.addScreenModel(newScreen, teaModel)
-				}
-			}
-		}
+	} else if _, exists := m.screenModels[newScreen]; !exists {
+		// Create the screen model using the navigation flow factory
+		m.addScreenModel(newScreen, m.newScreenModel(newScreen))
 	}
 	
 	return m, nil
@@ -531,6 +600,8 @@ func (m Model) getHelpText() string {
 		return components.RenderHelp("↑↓: navigate • space: toggle • enter: continue • backspace: back • esc: quit")
 	case models.AddAnotherAppScreen:
 		return components.RenderHelp("↑↓: navigate • enter: select • backspace: back • esc: quit")
+	case models.PackagesScreen:
+		return components.RenderHelp("↑↓: navigate • space/enter: select • s: skip • backspace: back • esc: quit")
 	case models.DevToolsScreen:
 		return components.RenderHelp("↑↓: navigate • enter: select • backspace: back • esc: quit")
 	case models.InfrastructureScreen:
@@ -541,6 +612,8 @@ func (m Model) getHelpText() string {
 		return components.RenderHelp("↑↓: navigate • space/enter: select • s: skip • backspace: back • esc: quit")
 	case models.YAMLPreviewScreen:
 		return components.RenderHelp("↑↓: navigate • enter: select • ctrl+j/k: scroll • backspace: back • esc: quit")
+	case models.ConflictScreen:
+		return components.RenderHelp("↑↓: navigate • enter: select • backspace: back • esc: quit")
 	case models.GeneratingScreen:
 		return ""
 	case models.CompleteScreen:
@@ -12,7 +12,7 @@ import (
 
 func RenderProgressIndicator(currentScreen models.Screen) string {
 	// Map screens to progress steps (skip welcome screen)
-	steps := []string{"Setup", "Architecture", "Apps", "Config", "Tools", "Infrastructure", "CI/CD", "AI Tools", "Preview"}
+	steps := []string{"Setup", "Architecture", "Apps", "Config", "Packages", "Tools", "Infrastructure", "CI/CD", "AI Tools", "Preview"}
 	screenToStep := map[models.Screen]int{
 		models.WelcomeScreen:        -1, // Not shown in progress
 		models.ProjectSetupScreen:   0,
@@ -20,13 +20,15 @@ func RenderProgressIndicator(currentScreen models.Screen) string {
 		models.AddAppsScreen:        2,
 		models.AppConfigScreen:      3,
 		models.AddAnotherAppScreen:  3, // Same step as app config
-		models.DevToolsScreen:       4,
-		models.InfrastructureScreen: 5,
-		models.CIPipelineScreen:     6,
-		models.AIToolsScreen:        7,
-		models.YAMLPreviewScreen:    8,
-		models.GeneratingScreen:     9,
-		models.CompleteScreen:       9,
+		models.PackagesScreen:       4,
+		models.DevToolsScreen:       5,
+		models.InfrastructureScreen: 6,
+		models.CIPipelineScreen:     7,
+		models.AIToolsScreen:        8,
+		models.YAMLPreviewScreen:    9,
+		models.ConflictScreen:       9, // Same step as preview
+		models.GeneratingScreen:     10,
+		models.CompleteScreen:       10,
 	}
 
 	currentStep := screenToStep[currentScreen]
@@ -6,6 +6,7 @@ import (
 	"time"
 
 	"teapot/internal/cache"
+	"teapot/internal/generator"
 	"teapot/internal/models"
 	"teapot/internal/ui/styles"
 	
@@ -84,7 +85,25 @@ func RenderProjectStructure(project models.ProjectConfig, terminalWidth, termina
 		Render("📁 " + project.Name + "/")
 	structure.WriteString(rootFolder + "\n")
 
-	if len(project.Applications) > 0 {
+	if project.Architecture == models.ArchitectureSingle {
+		// Single applications live at the repository root
+		for _, app := range project.Applications {
+			if configName := getAppConfigFile(app.Type); configName != "" {
+				configFile := lipgloss.NewStyle().
+					Foreground(styles.ColorTextSecondary).
+					Render("  ⚙️ " + configName)
+				structure.WriteString(configFile + "\n")
+			}
+			
+			if sourceName := generator.AppSourceFolder(app.Type); sourceName != "" {
+				srcFolder := lipgloss.NewStyle().
+					Foreground(styles.ColorTextNeon).
+					Bold(true).
+					Render("  📁 " + sourceName + "/")
+				structure.WriteString(srcFolder + "\n")
+			}
+		}
+	} else if len(project.Applications) > 0 {
 		appsFolder := lipgloss.NewStyle().
 			Foreground(styles.ColorAccent).
 			Bold(true).
@@ -96,7 +115,7 @@ func RenderProjectStructure(project models.ProjectConfig, terminalWidth, termina
 		structure.WriteString(appsFolder + "\n")
 		
 		for _, app := range project.Applications {
-			appName := getAppFolderName(string(app.Type))
+			appName := generator.AppFolderName(app)
 			appFolder := lipgloss.NewStyle().
 				Foreground(styles.ColorSuccess).
 				Bold(true).
@@ -110,27 +129,49 @@ func RenderProjectStructure(project models.ProjectConfig, terminalWidth, termina
 				Render("      📄 package.json")
 			structure.WriteString(packageJson + "\n")
 			
-			if app.Type == models.AppTypeNext {
-				configFile := lipgloss.NewStyle().
-					Foreground(styles.ColorTextSecondary).
-					Render("      ⚙️ next.config.js")
-				structure.WriteString(configFile + "\n")
-			} else if app.Type == models.AppTypeExpo {
+			if configName := getAppConfigFile(app.Type); configName != "" {
 				configFile := lipgloss.NewStyle().
 					Foreground(styles.ColorTextSecondary).
-					Render("      ⚙️ metro.config.js")
+					Render("      ⚙️ " + configName)
 				structure.WriteString(configFile + "\n")
 			}
 			
-			srcFolder := lipgloss.NewStyle().
-				Foreground(styles.ColorTextNeon).
-				Bold(true).
-				Render("      📁 src/")
-			structure.WriteString(srcFolder + "\n")
+			if project.Architecture == models.ArchitectureNx {
+				projectJson := lipgloss.NewStyle().
+					Foreground(styles.ColorSecondary).
+					Render("      ⚙️ project.json")
+				structure.WriteString(projectJson + "\n")
+			}
+			
+			if sourceName := generator.AppSourceFolder(app.Type); sourceName != "" {
+				srcFolder := lipgloss.NewStyle().
+					Foreground(styles.ColorTextNeon).
+					Bold(true).
+					Render("      📁 " + sourceName + "/")
+				structure.WriteString(srcFolder + "\n")
+			}
 		}
 	}
 
-	// TODO: Add packages section back later
+	if len(project.Packages) > 0 && project.Architecture != models.ArchitectureSingle {
+		packagesFolder := lipgloss.NewStyle().
+			Foreground(styles.ColorAccent).
+			Bold(true).
+			Padding(0, 1).
+			Background(styles.ColorBgTertiary).
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(styles.ColorBorderSecondary).
+			Render("  📁 packages/")
+		structure.WriteString(packagesFolder + "\n")
+		
+		for _, pkg := range project.Packages {
+			packageFolder := lipgloss.NewStyle().
+				Foreground(styles.ColorSuccess).
+				Bold(true).
+				Render("    📦 " + getPackageFolderName(models.PackageTypeNames[pkg]) + "/")
+			structure.WriteString(packageFolder + "\n")
+		}
+	}
 
 	// Infrastructure files based on configuration
 	if project.CIPipeline.Provider == "github" {
@@ -241,22 +282,21 @@ func GetStructureCacheStats() cache.CacheStats {
 	return cache.GetStats()
 }
 
-func getAppFolderName(appType string) string {
+// getAppConfigFile returns the main framework config file generated for an app type
+func getAppConfigFile(appType models.AppType) string {
 	switch appType {
-	case "next":
-		return "web"
-	case "expo":
-		return "mobile"
-	case "nest":
-		return "api"
-	case "react":
-		return "web"
-	case "tanstack-start":
-		return "web"
-	case "basic-node":
-		return "api"
+	case models.AppTypeReact:
+		return "vite.config.ts"
+	case models.AppTypeNext:
+		return "next.config.js"
+	case models.AppTypeTanStack:
+		return "app.config.ts"
+	case models.AppTypeExpo:
+		return "metro.config.js"
+	case models.AppTypeNest:
+		return "nest-cli.json"
 	default:
-		return "app"
+		return ""
 	}
 }
 
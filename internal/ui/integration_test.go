@@ -1,8 +1,13 @@
 package ui
 
 import (
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
+	"teapot/internal/errors"
+	"teapot/internal/generator"
 	"teapot/internal/models"
 	"teapot/internal/ui/screens"
 
@@ -89,11 +94,22 @@ func TestCompleteUserFlow(t *testing.T) {
 	model = updateModel(model, screens.AddAnotherAppSelectedMsg{
 		Action: "continue",
 	})
+	if model.state.CurrentScreen != models.PackagesScreen {
+		t.Errorf("Expected screen to be PackagesScreen after choosing to continue, got %v", model.state.CurrentScreen)
+	}
+	
+	// Test 8: Shared packages selection
+	model = updateModel(model, screens.PackagesSelectedMsg{
+		Packages: []models.PackageType{models.PackageUI, models.PackageUtils},
+	})
 	if model.state.CurrentScreen != models.DevToolsScreen {
-		t.Errorf("Expected screen to be DevToolsScreen after choosing to continue, got %v", model.state.CurrentScreen)
+		t.Errorf("Expected screen to be DevToolsScreen after packages, got %v", model.state.CurrentScreen)
+	}
+	if len(model.state.Project.Packages) != 2 {
+		t.Errorf("Expected 2 shared packages, got %d", len(model.state.Project.Packages))
 	}
 	
-	// Test 8: Dev tools selection
+	// Test 8b: Dev tools selection
 	model = updateModel(model, screens.DevToolsSelectedMsg{
 		LintingTool: "prettier-eslint",
 	})
@@ -145,7 +161,7 @@ func TestCompleteUserFlow(t *testing.T) {
 	}
 	
 	// Test 12: YAML preview continue
-	model = updateModel(model, screens.YAMLContinueMsg{Project: model.state.Project})
+	model = updateModel(model, screens.YAMLContinueMsg{})
 	if model.state.CurrentScreen != models.GeneratingScreen {
 		t.Errorf("Expected screen to be GeneratingScreen after YAML continue, got %v", model.state.CurrentScreen)
 	}
@@ -184,6 +200,7 @@ func TestBackNavigationFlow(t *testing.T) {
 	model = updateModel(model, screens.AppTypeSelectedMsg{AppType: models.AppTypeReact})
 	model = updateModel(model, screens.AppConfigCompleteMsg{AppName: "web", Options: map[string]interface{}{}})
 	model = updateModel(model, screens.AddAnotherAppSelectedMsg{Action: "continue"})
+	model = updateModel(model, screens.PackagesSelectedMsg{})
 	
 	// Should be at DevToolsScreen
 	if model.state.CurrentScreen != models.DevToolsScreen {
@@ -192,6 +209,12 @@ func TestBackNavigationFlow(t *testing.T) {
 	
 	// Test back navigation
 	model, _ = model.navigateBack()
+	if model.state.CurrentScreen != models.PackagesScreen {
+		t.Errorf("Expected back navigation to PackagesScreen, got %v", model.state.CurrentScreen)
+	}
+	
+	// Navigate back to the add another app screen
+	model, _ = model.navigateBack()
 	if model.state.CurrentScreen != models.AddAnotherAppScreen {
 		t.Errorf("Expected back navigation to AddAnotherAppScreen, got %v", model.state.CurrentScreen)
 	}
@@ -215,6 +238,452 @@ func TestBackNavigationFlow(t *testing.T) {
 	}
 }
 
+// TestSingleAppSkipsPackages tests that single applications skip the shared packages screen
+func TestSingleAppSkipsPackages(t *testing.T) {
+	model := NewModel()
+	
+	model = updateModel(model, screens.WelcomeCompleteMsg{})
+	model = updateModel(model, screens.ProjectSetupCompleteMsg{ProjectName: "solo", Description: ""})
+	model = updateModel(model, screens.ArchitectureSelectedMsg{Architecture: models.ArchitectureSingle})
+	model = updateModel(model, screens.AppTypeSelectedMsg{AppType: models.AppTypeNext})
+	model = updateModel(model, screens.AppConfigCompleteMsg{AppName: "web", Options: map[string]interface{}{}})
+	model = updateModel(model, screens.AddAnotherAppSelectedMsg{Action: "continue"})
+	
+	if model.state.CurrentScreen != models.DevToolsScreen {
+		t.Errorf("Expected single app to continue to DevToolsScreen, got %v", model.state.CurrentScreen)
+	}
+	
+	model, _ = model.navigateBack()
+	if model.state.CurrentScreen != models.AddAnotherAppScreen {
+		t.Errorf("Expected back navigation to AddAnotherAppScreen, got %v", model.state.CurrentScreen)
+	}
+}
+
+// TestPrefilledProjectSkipsSetup tests that a project name from the command line skips answered screens
+func TestPrefilledProjectSkipsSetup(t *testing.T) {
+	model := NewModelWithProject(models.ProjectConfig{Name: "my-awesome-project"})
+
+	if model.state.CurrentScreen != models.ArchitectureScreen {
+		t.Errorf("Expected prefilled project to start at ArchitectureScreen, got %v", model.state.CurrentScreen)
+	}
+	if model.state.Project.Name != "my-awesome-project" {
+		t.Errorf("Expected project name to be prefilled, got %s", model.state.Project.Name)
+	}
+
+	// Back navigation still reaches the prefilled setup screen
+	model, _ = model.navigateBack()
+	if model.state.CurrentScreen != models.ProjectSetupScreen {
+		t.Errorf("Expected back navigation to ProjectSetupScreen, got %v", model.state.CurrentScreen)
+	}
+	if view := model.screenModels[models.ProjectSetupScreen].View(); !strings.Contains(view, "my-awesome-project") {
+		t.Error("Expected project setup screen to show the prefilled name")
+	}
+
+	// Without a name the wizard starts from the beginning
+	model = NewModelWithProject(models.ProjectConfig{})
+	if model.state.CurrentScreen != models.WelcomeScreen {
+		t.Errorf("Expected empty project to start at WelcomeScreen, got %v", model.state.CurrentScreen)
+	}
+}
+
+// TestPrefilledFlagsSkipAnsweredScreens tests that prefilled answers skip their screens while
+// back navigation still reaches them for editing
+func TestPrefilledFlagsSkipAnsweredScreens(t *testing.T) {
+	model := NewModelWithProject(models.ProjectConfig{
+		Name:         "demo",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{
+			{ID: "app-next", Name: "web", Type: models.AppTypeNext, Options: map[string]interface{}{}},
+			{ID: "app-nest", Name: "api", Type: models.AppTypeNest, Options: map[string]interface{}{}},
+		},
+		DevTools:   models.DevTools{Linting: "biome", TypeScript: true},
+		CIPipeline: models.CIPipeline{Provider: "github", Features: []string{"testing"}},
+	})
+
+	if model.state.CurrentScreen != models.PackagesScreen {
+		t.Fatalf("Expected to start at PackagesScreen, got %v", model.state.CurrentScreen)
+	}
+
+	// Dev tools were prefilled, so packages continue straight to infrastructure
+	model = updateModel(model, screens.PackagesSelectedMsg{})
+	if model.state.CurrentScreen != models.InfrastructureScreen {
+		t.Errorf("Expected to skip to InfrastructureScreen, got %v", model.state.CurrentScreen)
+	}
+
+	// Back navigation still reaches the prefilled dev tools screen
+	model, _ = model.navigateBack()
+	if model.state.CurrentScreen != models.DevToolsScreen {
+		t.Errorf("Expected back navigation to DevToolsScreen, got %v", model.state.CurrentScreen)
+	}
+	model = updateModel(model, screens.DevToolsSelectedMsg{LintingTool: "prettier-eslint"})
+	if model.state.Project.DevTools.Linting != "prettier-eslint" {
+		t.Errorf("Expected edited linting tool, got %s", model.state.Project.DevTools.Linting)
+	}
+
+	// CI/CD was prefilled, so infrastructure continues to AI tools
+	model = updateModel(model, screens.InfrastructureSelectedMsg{Options: map[string]bool{}})
+	if model.state.CurrentScreen != models.AIToolsScreen {
+		t.Errorf("Expected to skip to AIToolsScreen, got %v", model.state.CurrentScreen)
+	}
+
+	// Prefilled applications can be edited through back navigation
+	model = NewModelWithProject(model.state.Project)
+	model, _ = model.navigateBack()
+	if model.state.CurrentScreen != models.AddAnotherAppScreen {
+		t.Fatalf("Expected back navigation to AddAnotherAppScreen, got %v", model.state.CurrentScreen)
+	}
+	model, _ = model.navigateBack()
+	if model.state.CurrentScreen != models.AppConfigScreen || len(model.state.Project.Applications) != 1 {
+		t.Errorf("Expected to reconfigure the last application, got %v with %d apps", model.state.CurrentScreen, len(model.state.Project.Applications))
+	}
+}
+
+// TestYAMLPreviewFiles tests toggling the preview between teapot.yml and the file plan
+func TestYAMLPreviewFiles(t *testing.T) {
+	var preview tea.Model = screens.NewYAMLPreviewModel(models.ProjectConfig{
+		Name:         "demo",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{
+			{ID: "app-next", Name: "web", Type: models.AppTypeNext, Options: map[string]interface{}{}},
+		},
+		DevTools:   models.DevTools{Linting: "biome", TypeScript: true},
+		CIPipeline: models.CIPipeline{Provider: "skip", Features: []string{}},
+	})
+
+	if !strings.Contains(preview.View(), "architecture: turborepo") {
+		t.Error("Expected the preview to start with teapot.yml")
+	}
+
+	// Move from "Continue to Generation" up to "Preview files"
+	preview, _ = preview.Update(tea.KeyMsg{Type: tea.KeyUp})
+	preview, _ = preview.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	view := preview.View()
+	for _, expected := range []string{"apps/web/package.json", "(generated)", "Preview teapot.yml"} {
+		if !strings.Contains(view, expected) {
+			t.Errorf("Expected file preview to contain %q", expected)
+		}
+	}
+
+	preview, _ = preview.Update(tea.KeyMsg{Type: tea.KeyEnter})
+	if !strings.Contains(preview.View(), "architecture: turborepo") {
+		t.Error("Expected the preview to toggle back to teapot.yml")
+	}
+}
+
+// TestAcceptingDefaultsMatchesHeadless tests that confirming every screen without
+// changes gives the project teapot init --yes generates
+func TestAcceptingDefaultsMatchesHeadless(t *testing.T) {
+	down := make([]tea.KeyMsg, 10)
+	for i := range down {
+		down[i] = tea.KeyMsg{Type: tea.KeyDown}
+	}
+	enter := tea.KeyMsg{Type: tea.KeyEnter}
+	// The keys that confirm each screen; list screens end with a continue option
+	keys := map[models.Screen][]tea.KeyMsg{
+		models.ArchitectureScreen:   {enter},
+		models.AddAppsScreen:        {enter},
+		models.AppConfigScreen:      {enter, enter},
+		models.AddAnotherAppScreen:  append(down, enter),
+		models.PackagesScreen:       append(down, enter),
+		models.DevToolsScreen:       append(down, enter),
+		models.InfrastructureScreen: append(down, enter),
+		models.CIPipelineScreen:     append([]tea.KeyMsg{{Type: tea.KeyTab}}, append(down, enter)...),
+		models.AIToolsScreen:        append(down, enter),
+	}
+
+	model := NewModelWithProject(models.ProjectConfig{Name: "demo"})
+	for steps := 0; model.state.CurrentScreen != models.YAMLPreviewScreen; steps++ {
+		screenKeys, ok := keys[model.state.CurrentScreen]
+		if !ok || steps > len(keys) {
+			t.Fatalf("Expected to reach the YAML preview, stuck at %v", model.state.CurrentScreen)
+		}
+		for _, key := range screenKeys {
+			updated, cmd := model.Update(key)
+			model = updated.(Model)
+			if cmd != nil {
+				model = updateModel(model, cmd())
+			}
+		}
+	}
+
+	wizard, err := generator.GenerateTeapotYAML(model.state.Project)
+	if err != nil {
+		t.Fatal(err)
+	}
+	headless, err := generator.GenerateTeapotYAML(screens.Defaults().Apply(models.ProjectConfig{Name: "demo"}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if wizard != headless {
+		t.Errorf("Expected the wizard defaults to match headless mode, got:\n%s\nwant:\n%s", wizard, headless)
+	}
+}
+
+func TestProjectSetupPackageManager(t *testing.T) {
+	model := updateModel(NewModel(), screens.WelcomeCompleteMsg{})
+
+	// Name, description, then one step right from the preselected Bun
+	for _, key := range []tea.KeyMsg{
+		{Type: tea.KeyRunes, Runes: []rune("demo")},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyEnter},
+		{Type: tea.KeyRight},
+		{Type: tea.KeyEnter},
+	} {
+		updated, cmd := model.Update(key)
+		model = updated.(Model)
+		if cmd != nil {
+			model = updateModel(model, cmd())
+		}
+	}
+
+	if model.state.CurrentScreen != models.ArchitectureScreen {
+		t.Fatalf("Expected the project setup to complete, stuck at %v", model.state.CurrentScreen)
+	}
+	if model.state.Project.Name != "demo" || model.state.Project.PackageManager != models.PackageManagerPnpm {
+		t.Errorf("Expected project demo using pnpm, got %+v", model.state.Project)
+	}
+
+	view := screens.NewCompleteModel("demo", model.state.Project.PackageManager).View()
+	if !strings.Contains(view, "pnpm install") || strings.Contains(view, "bun") {
+		t.Errorf("Expected the next steps to use pnpm, got:\n%s", view)
+	}
+}
+
+// chdirTemp switches to a fresh temporary directory for the rest of the test
+func chdirTemp(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(dir); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { os.Chdir(wd) })
+	return dir
+}
+
+// previewModel returns a model showing the YAML preview for a complete project
+func previewModel() Model {
+	model := NewModelWithProject(models.ProjectConfig{
+		Name:         "demo",
+		Architecture: models.ArchitectureTurborepo,
+		Applications: []models.Application{
+			{ID: "app-next", Name: "web", Type: models.AppTypeNext, Options: map[string]interface{}{}},
+		},
+		DevTools:   models.DevTools{Linting: "biome", TypeScript: true},
+		CIPipeline: models.CIPipeline{Provider: "skip", Features: []string{}},
+	})
+	model.state.CurrentScreen = models.YAMLPreviewScreen
+	model.addScreenModel(models.YAMLPreviewScreen, model.newScreenModel(models.YAMLPreviewScreen))
+	return model
+}
+
+// TestGenerationConflict tests the confirmation shown when ./<project name> already has content
+func TestGenerationConflict(t *testing.T) {
+	dir := chdirTemp(t)
+	if err := os.MkdirAll(filepath.Join(dir, "demo", ".git"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	model := previewModel()
+	model = updateModel(model, screens.YAMLContinueMsg{})
+	if model.state.CurrentScreen != models.ConflictScreen {
+		t.Fatalf("Expected ConflictScreen for an existing directory, got %v", model.state.CurrentScreen)
+	}
+	if view := model.screenModels[models.ConflictScreen].View(); !strings.Contains(view, "a git repository") {
+		t.Errorf("Expected the conflict screen to describe the directory, got:\n%s", view)
+	}
+
+	// Aborting returns to the preview without generating
+	model = updateModel(model, screens.ConflictResolvedMsg{Policy: generator.ConflictAbort})
+	if model.state.CurrentScreen != models.YAMLPreviewScreen {
+		t.Errorf("Expected abort to return to YAMLPreviewScreen, got %v", model.state.CurrentScreen)
+	}
+
+	model = updateModel(model, screens.YAMLContinueMsg{})
+	model = updateModel(model, screens.ConflictResolvedMsg{Policy: generator.ConflictMerge})
+	if model.state.CurrentScreen != models.GeneratingScreen {
+		t.Errorf("Expected merge to start generation, got %v", model.state.CurrentScreen)
+	}
+
+	// A missing directory goes straight to generation
+	model = previewModel()
+	model.state.Project.Name = "fresh"
+	model = updateModel(model, screens.YAMLContinueMsg{})
+	if model.state.CurrentScreen != models.GeneratingScreen {
+		t.Errorf("Expected a missing directory to skip the conflict screen, got %v", model.state.CurrentScreen)
+	}
+}
+
+// TestInstallDependencies tests the install log and the retry and skip keys after a failed install
+func TestInstallDependencies(t *testing.T) {
+	chdirTemp(t)
+	key := func(model Model, key string) (Model, tea.Cmd) {
+		updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune(key)})
+		return updated.(Model), cmd
+	}
+
+	model := previewModel()
+	model = updateModel(model, screens.YAMLContinueMsg{})
+	if model.state.CurrentScreen != models.GeneratingScreen {
+		t.Fatalf("Expected GeneratingScreen, got %v", model.state.CurrentScreen)
+	}
+
+	// The engine is not run here; the install starts once generation has finished
+	model = updateModel(model, screens.GenerationFinishedMsg{})
+	model = updateModel(model, screens.InstallOutputMsg{Line: "error: lockfile had changes"})
+	installErr := errors.NewSystemError("bun install exited with code 1", nil)
+	installErr.Recoverable = true
+	model = updateModel(model, screens.InstallFinishedMsg{Err: installErr})
+
+	view := model.screenModels[models.GeneratingScreen].View()
+	for _, want := range []string{"$ bun install", "lockfile had changes", "bun install exited with code 1", "r: retry"} {
+		if !strings.Contains(view, want) {
+			t.Errorf("Expected the generating screen to show %q, got:\n%s", want, view)
+		}
+	}
+
+	// Retrying starts the package manager again
+	model, cmd := key(model, "r")
+	if cmd == nil || strings.Contains(model.screenModels[models.GeneratingScreen].View(), "r: retry") {
+		t.Error("Expected r to retry the install")
+	}
+
+	// Skipping after another failure finishes without dependencies
+	model = updateModel(model, screens.InstallFinishedMsg{Err: installErr})
+	model, cmd = key(model, "s")
+	if cmd == nil {
+		t.Fatal("Expected s to skip the install")
+	}
+	model = updateModel(model, cmd())
+	if model.state.CurrentScreen != models.CompleteScreen {
+		t.Errorf("Expected skipping to reach CompleteScreen, got %v", model.state.CurrentScreen)
+	}
+}
+
+// TestCancelGeneration tests that esc and ctrl+c wait for the engine to stop before quitting
+func TestCancelGeneration(t *testing.T) {
+	chdirTemp(t)
+	isQuit := func(cmd tea.Cmd) bool {
+		if cmd == nil {
+			return false
+		}
+		_, ok := cmd().(tea.QuitMsg)
+		return ok
+	}
+
+	for _, key := range []tea.KeyMsg{{Type: tea.KeyEsc}, {Type: tea.KeyCtrlC}} {
+		model := previewModel()
+		model = updateModel(model, screens.YAMLContinueMsg{})
+
+		// The engine is not run here; the key only asks it to stop
+		updated, cmd := model.Update(key)
+		model = updated.(Model)
+		if isQuit(cmd) {
+			t.Fatalf("Expected %s not to quit while the engine is running", key)
+		}
+		if view := model.screenModels[models.GeneratingScreen].View(); !strings.Contains(view, "Cancelling") {
+			t.Errorf("Expected the generating screen to show the cancellation, got:\n%s", view)
+		}
+
+		cancelled := errors.NewSystemError("generating demo was cancelled", nil)
+		_, cmd = model.Update(screens.GenerationFinishedMsg{Err: cancelled})
+		if !isQuit(cmd) {
+			t.Errorf("Expected %s to quit once the engine has stopped", key)
+		}
+	}
+}
+
+// TestSaveTeapotYAMLConfirmsOverwrite tests that saving never replaces teapot.yml silently
+func TestSaveTeapotYAMLConfirmsOverwrite(t *testing.T) {
+	dir := chdirTemp(t)
+	if err := os.WriteFile(filepath.Join(dir, "teapot.yml"), []byte("mine"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	model := previewModel()
+	model = updateModel(model, screens.YAMLSaveMsg{})
+	if view := model.screenModels[models.YAMLPreviewScreen].View(); !strings.Contains(view, "Overwrite it?") {
+		t.Fatalf("Expected an overwrite confirmation, got:\n%s", view)
+	}
+	if content, _ := os.ReadFile(filepath.Join(dir, "teapot.yml")); string(content) != "mine" {
+		t.Fatal("Expected teapot.yml to be kept until confirmed")
+	}
+
+	// Confirming sends the save again with overwrite enabled
+	updated, cmd := model.Update(tea.KeyMsg{Type: tea.KeyRunes, Runes: []rune("y")})
+	model = updated.(Model)
+	if cmd == nil {
+		t.Fatal("Expected confirming to save teapot.yml")
+	}
+	model = updateModel(model, cmd())
+	if content, _ := os.ReadFile(filepath.Join(dir, "teapot.yml")); string(content) == "mine" {
+		t.Error("Expected teapot.yml to be overwritten after confirming")
+	}
+	if view := model.screenModels[models.YAMLPreviewScreen].View(); !strings.Contains(view, "Saved teapot.yml") {
+		t.Errorf("Expected a saved status, got:\n%s", view)
+	}
+}
+
+// TestYAMLPreviewAfterGoingBack tests that the preview and the saved teapot.yml
+// follow answers changed after going back from the preview
+func TestYAMLPreviewAfterGoingBack(t *testing.T) {
+	dir := chdirTemp(t)
+
+	model := previewModel()
+	model = updateModel(model, screens.YAMLBackMsg{})
+	if model.state.CurrentScreen != models.AIToolsScreen {
+		t.Fatalf("Expected going back to AIToolsScreen, got %v", model.state.CurrentScreen)
+	}
+	model = updateModel(model, screens.AIToolsSelectedMsg{Editor: "cursor"})
+
+	// aiTools is at the end of teapot.yml
+	for i := 0; i < 10; i++ {
+		model = updateModel(model, tea.KeyMsg{Type: tea.KeyCtrlJ})
+	}
+	if view := model.screenModels[models.YAMLPreviewScreen].View(); !strings.Contains(view, "editor: cursor") {
+		t.Errorf("Expected the preview to show the new answer, got:\n%s", view)
+	}
+	model = updateModel(model, screens.YAMLSaveMsg{})
+	if content, _ := os.ReadFile(filepath.Join(dir, "teapot.yml")); !strings.Contains(string(content), "editor: cursor") {
+		t.Errorf("Expected the saved teapot.yml to have the new answer, got:\n%s", content)
+	}
+}
+
+// TestAddAppFlow tests the application screens run on their own for teapot add app
+func TestAddAppFlow(t *testing.T) {
+	var model tea.Model = NewAddAppModel(previewModel().state.Project)
+
+	model, _ = model.Update(screens.AppTypeSelectedMsg{AppType: models.AppTypeNest})
+	if screen := model.(AddAppModel).screen; screen != models.AppConfigScreen {
+		t.Fatalf("Expected AppConfigScreen after choosing a type, got %v", screen)
+	}
+
+	// A name that clashes with an existing application keeps the flow open
+	model, cmd := model.Update(screens.AppConfigCompleteMsg{AppName: "web", Options: map[string]interface{}{}})
+	if cmd != nil {
+		t.Error("Expected a clashing name not to quit")
+	}
+	if _, ok := model.(AddAppModel).Application(); ok {
+		t.Error("Expected no application for a clashing name")
+	}
+	if view := model.View(); !strings.Contains(view, "web") {
+		t.Errorf("Expected the clash to be explained, got:\n%s", view)
+	}
+
+	model, cmd = model.Update(screens.AppConfigCompleteMsg{AppName: "api", Options: map[string]interface{}{}})
+	if cmd == nil {
+		t.Error("Expected the flow to quit once the application is configured")
+	}
+	app, ok := model.(AddAppModel).Application()
+	if !ok || app.Name != "api" || app.Type != models.AppTypeNest {
+		t.Errorf("Expected the configured nest application, got %+v (ok=%v)", app, ok)
+	}
+}
+
 // TestWindowResizing tests window resize handling
 func TestWindowResizing(t *testing.T) {
 	model := NewModel()
@@ -15,7 +15,7 @@ type AddAppsModel struct {
 }
 
 func NewAddAppsModel() AddAppsModel {
-	return AddAppsModel{
+	m := AddAppsModel{
 		apps: []models.AppType{
 			models.AppTypeReact,
 			models.AppTypeNext,
@@ -24,8 +24,13 @@ func NewAddAppsModel() AddAppsModel {
 			models.AppTypeNest,
 			models.AppTypeBasicNode,
 		},
-		cursor: 0,
 	}
+	for i, app := range m.apps {
+		if app == Defaults().AppType {
+			m.cursor = i
+		}
+	}
+	return m
 }
 
 func (m AddAppsModel) Init() tea.Cmd {
@@ -24,7 +24,7 @@ type EditorOption struct {
 }
 
 func NewAIToolsModel() AIToolsModel {
-	return AIToolsModel{
+	m := AIToolsModel{
 		editors: []EditorOption{
 			{
 				Key:         "claude-code",
@@ -77,6 +77,31 @@ func NewAIToolsModel() AIToolsModel {
 		},
 		cursor: 0,
 	}
+	for i, editor := range m.editors {
+		m.editors[i].Selected = !editor.IsContinue && containsKey(Defaults().AITools, editor.Key)
+	}
+	return m
+}
+
+// AITools returns the keys of the tools offered by the AI tools screen.
+func AITools() []string {
+	var keys []string
+	for _, editor := range NewAIToolsModel().editors {
+		if !editor.IsContinue {
+			keys = append(keys, editor.Key)
+		}
+	}
+	return keys
+}
+
+// AIToolExtensions returns the extensions configured for an AI tool, or false if the tool is unknown.
+func AIToolExtensions(key string) ([]string, bool) {
+	for _, editor := range NewAIToolsModel().editors {
+		if !editor.IsContinue && editor.Key == key {
+			return editor.Extensions, true
+		}
+	}
+	return nil, false
 }
 
 func (m AIToolsModel) Init() tea.Cmd {
@@ -4,6 +4,7 @@ import (
 	"teapot/internal/models"
 	"teapot/internal/ui/components"
 	"teapot/internal/ui/styles"
+	"teapot/internal/validation"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
@@ -277,15 +278,32 @@ func getDefaultAppName(appType models.AppType) string {
 	}
 }
 
-func isValidAppNameChar(char string) bool {
-	if len(char) != 1 {
-		return false
+// DefaultAppName returns the name the app configuration screen suggests for an application type.
+func DefaultAppName(appType models.AppType) string {
+	return getDefaultAppName(appType)
+}
+
+// AppOptions returns the options the app configuration screen offers for an application type.
+func AppOptions(appType models.AppType) []AppConfigOption {
+	return getOptionsForAppType(appType)
+}
+
+// DefaultAppOptions returns the options the app configuration screen preselects for an application type.
+func DefaultAppOptions(appType models.AppType) map[string]interface{} {
+	options := make(map[string]interface{})
+	for _, option := range getOptionsForAppType(appType) {
+		options[option.Key] = option.Selected
 	}
-	c := char[0]
-	return (c >= 'a' && c <= 'z') || 
-		   (c >= 'A' && c <= 'Z') || 
-		   (c >= '0' && c <= '9') || 
-		   c == '-' || c == '_'
+	return options
+}
+
+// IsValidAppName reports whether a name can be typed into the app configuration screen.
+func IsValidAppName(name string) bool {
+	return validation.ValidateAppName(name) == nil
+}
+
+func isValidAppNameChar(char string) bool {
+	return len(char) == 1 && validation.IsValidAppNameChar(rune(char[0]))
 }
 
 type AppConfigCompleteMsg struct {
@@ -15,15 +15,20 @@ type ArchitectureModel struct {
 }
 
 func NewArchitectureModel() ArchitectureModel {
-	return ArchitectureModel{
+	m := ArchitectureModel{
 		options: []models.ArchitectureType{
 			models.ArchitectureTurborepo,
 			models.ArchitectureSingle,
 			models.ArchitectureNx,
 			"continue", // Special continue option
 		},
-		cursor: 0,
 	}
+	for i, option := range m.options {
+		if option == Defaults().Architecture {
+			m.cursor = i
+		}
+	}
+	return m
 }
 
 func (m ArchitectureModel) Init() tea.Cmd {
@@ -43,11 +48,9 @@ func (m ArchitectureModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor--
 			}
 		case "enter":
-			if m.options[m.cursor] != models.ArchitectureNx { // Nx is coming soon
-				return m, func() tea.Msg {
-					return ArchitectureSelectedMsg{
-						Architecture: m.options[m.cursor],
-					}
+			return m, func() tea.Msg {
+				return ArchitectureSelectedMsg{
+					Architecture: m.options[m.cursor],
 				}
 			}
 		}
@@ -79,7 +82,7 @@ func (m ArchitectureModel) View() string {
 			optionStyle = styles.UnselectedStyle
 			descriptionText = "Simple single application setup"
 		case models.ArchitectureNx:
-			optionStyle = styles.UnselectedStyle.Copy().Foreground(styles.ColorTextMuted)
+			optionStyle = styles.UnselectedStyle
 			descriptionText = "Enterprise-grade monorepo tools"
 		}
 
@@ -91,9 +94,6 @@ func (m ArchitectureModel) View() string {
 		}
 
 		name := models.ArchitectureNames[option]
-		if option == models.ArchitectureNx {
-			name += " 🚧"
-		}
 
 		// Enhanced choice rendering with unified styling
 		choiceText := cursor + " " + checked + " " + name
@@ -32,7 +32,7 @@ type FeatureOption struct {
 }
 
 func NewCIPipelineModel() CIPipelineModel {
-	return CIPipelineModel{
+	m := CIPipelineModel{
 		providers: []ProviderOption{
 			{"github", "GitHub Actions", "Integrated with GitHub repositories"},
 			{"gitlab", "GitLab CI", "GitLab's built-in CI/CD system"},
@@ -40,18 +40,52 @@ func NewCIPipelineModel() CIPipelineModel {
 			{"skip", "Skip CI/CD", "Set up CI/CD later manually"},
 		},
 		features: []FeatureOption{
-			{"testing", "Testing", "Run tests on every push", true, false},
-			{"linting", "Linting & Formatting", "Code quality checks", true, false},
+			{"testing", "Testing", "Run tests on every push", false, false},
+			{"linting", "Linting & Formatting", "Code quality checks", false, false},
 			{"docker", "Docker Image Build", "Build and push container images", false, false},
 			{"deployment", "Automatic Deployment", "Deploy on successful builds", false, false},
 			{"security", "Security Scanning", "Vulnerability and dependency checks", false, false},
 			{"continue", "Continue", "Proceed with selected configuration", false, true},
 		},
-		providerIdx:      0,
-		featureIdx:       0,
-		currentArea:      0,
-		selectedProvider: 0, // Default to GitHub Actions
+		featureIdx:  0,
+		currentArea: 0,
 	}
+
+	defaults := Defaults()
+	for i, provider := range m.providers {
+		if provider.Key == defaults.CIProvider {
+			m.providerIdx = i
+			m.selectedProvider = i
+		}
+	}
+	for i, feature := range m.features {
+		m.features[i].Selected = !feature.IsContinue && containsKey(defaults.CIFeatures, feature.Key)
+	}
+	return m
+}
+
+// CIProviders returns the keys of the providers offered by the CI/CD screen.
+func CIProviders() []string {
+	var keys []string
+	for _, provider := range NewCIPipelineModel().providers {
+		keys = append(keys, provider.Key)
+	}
+	return keys
+}
+
+// CIFeatures returns the keys of the pipeline features offered by the CI/CD screen
+// together with the ones it preselects.
+func CIFeatures() (keys []string, defaults []string) {
+	for _, feature := range NewCIPipelineModel().features {
+		if feature.IsContinue {
+			continue
+		}
+		keys = append(keys, feature.Key)
+		if feature.Selected {
+			defaults = append(defaults, feature.Key)
+		}
+	}
+	return keys, defaults
 }
 
 func (m CIPipelineModel) Init() tea.Cmd {
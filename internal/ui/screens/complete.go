@@ -1,6 +1,10 @@
 package screens
 
 import (
+	"fmt"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
 	"teapot/internal/ui/components"
 	"teapot/internal/ui/styles"
 
@@ -8,12 +12,19 @@ import (
 )
 
 type CompleteModel struct {
-	projectName string
+	projectName    string
+	packageManager models.PackageManagerType
 }
 
-func NewCompleteModel(projectName string) CompleteModel {
+// NewCompleteModel creates the completion screen; its next steps use the project's
+// package manager, Bun when none is set.
+func NewCompleteModel(projectName string, packageManager models.PackageManagerType) CompleteModel {
+	if packageManager == "" {
+		packageManager = models.PackageManagerBun
+	}
 	return CompleteModel{
-		projectName: projectName,
+		projectName:    projectName,
+		packageManager: packageManager,
 	}
 }
 
@@ -39,14 +50,14 @@ func (m CompleteModel) View() string {
 
 	nextSteps := "Next steps:\n" +
 		"1. cd " + m.projectName + "\n" +
-		"2. pnpm install\n" +
-		"3. pnpm dev"
+		"2. " + generator.InstallCommand(m.packageManager) + "\n" +
+		"3. " + generator.RunCommand(m.packageManager, "dev")
 
 	commands := "Available commands:\n" +
-		"• pnpm dev      - Start all apps\n" +
-		"• pnpm build    - Build all apps\n" +
-		"• pnpm lint     - Lint all packages\n" +
-		"• pnpm test     - Run tests"
+		fmt.Sprintf("• %-14s - Start all apps\n", generator.RunCommand(m.packageManager, "dev")) +
+		fmt.Sprintf("• %-14s - Build all apps\n", generator.RunCommand(m.packageManager, "build")) +
+		fmt.Sprintf("• %-14s - Lint all packages\n", generator.RunCommand(m.packageManager, "lint")) +
+		fmt.Sprintf("• %-14s - Run tests", generator.RunCommand(m.packageManager, "test"))
 
 	footer := styles.CheckedStyle.Render("Happy coding! 🚀")
 
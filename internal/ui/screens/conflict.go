@@ -0,0 +1,98 @@
+package screens
+
+import (
+	"fmt"
+
+	"teapot/internal/generator"
+	"teapot/internal/ui/components"
+	"teapot/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// ConflictModel asks how to generate into a target directory that already has content
+type ConflictModel struct {
+	targetDir string
+	state     generator.TargetState
+	options   []ConflictOption
+	cursor    int
+}
+
+type ConflictOption struct {
+	Policy      generator.ConflictPolicy
+	Name        string
+	Description string
+}
+
+func NewConflictModel(targetDir string, state generator.TargetState) ConflictModel {
+	return ConflictModel{
+		targetDir: targetDir,
+		state:     state,
+		options: []ConflictOption{
+			{generator.ConflictAbort, "Abort", "Leave the directory untouched and return to the preview"},
+			{generator.ConflictMerge, "Merge", "Write only the files that do not exist yet"},
+			{generator.ConflictOverwrite, "Overwrite with backup", "Move the directory to a timestamped backup and generate a fresh project"},
+		},
+		cursor: 0, // Default to the safest choice
+	}
+}
+
+func (m ConflictModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m ConflictModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "enter":
+			policy := m.options[m.cursor].Policy
+			return m, func() tea.Msg {
+				return ConflictResolvedMsg{Policy: policy}
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m ConflictModel) View() string {
+	subtitle := components.RenderSubtitle("⚠️  Target Directory Exists")
+
+	warning := lipgloss.NewStyle().
+		Foreground(styles.ColorError).
+		Bold(true).
+		Margin(1, 0, 1, 0).
+		Render(fmt.Sprintf("./%s already exists and is %s", m.targetDir, m.state))
+
+	var choices string
+	for i, option := range m.options {
+		optionStyle := styles.UnselectedStyle
+		if m.cursor == i {
+			optionStyle = styles.FocusedStyle
+		}
+
+		choice := optionStyle.Render("  → " + option.Name)
+		description := lipgloss.NewStyle().
+			Foreground(styles.ColorTextMuted).
+			Margin(0, 0, 0, 4).
+			Render(option.Description)
+
+		choices += choice + "\n" + description + "\n\n"
+	}
+
+	return subtitle + "\n\n" + warning + "\n" + choices
+}
+
+// ConflictResolvedMsg carries the policy chosen for an existing target directory
+type ConflictResolvedMsg struct {
+	Policy generator.ConflictPolicy
+}
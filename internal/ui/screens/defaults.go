@@ -0,0 +1,103 @@
+package screens
+
+import (
+	"strings"
+
+	"teapot/internal/generator"
+	"teapot/internal/models"
+)
+
+// WizardDefaults holds the answer every wizard screen preselects.
+type WizardDefaults struct {
+	// PackageManager is preselected on the project setup screen
+	PackageManager models.PackageManagerType
+	// Architecture is preselected on the architecture screen
+	Architecture models.ArchitectureType
+	// AppType is preselected on the add apps screen
+	AppType models.AppType
+	// Packages are preselected on the shared packages screen
+	Packages []models.PackageType
+	// Linting is the linting setup preselected on the dev tools screen
+	Linting string
+	// Infrastructure holds the options preselected on the infrastructure screen
+	Infrastructure models.Infrastructure
+	// CIProvider is the provider preselected on the CI/CD screen
+	CIProvider string
+	// CIFeatures are the pipeline features preselected on the CI/CD screen
+	CIFeatures []string
+	// AITools are the tools preselected on the AI tools screen
+	AITools []string
+}
+
+// Defaults returns the answers the wizard preselects. The screen constructors
+// start from this table and headless mode accepts it as is, so the two never
+// disagree.
+func Defaults() WizardDefaults {
+	return WizardDefaults{
+		PackageManager: models.PackageManagerBun,
+		Architecture:   models.ArchitectureTurborepo,
+		AppType:        models.AppTypeReact,
+		Packages:       []models.PackageType{},
+		Linting:        "prettier-eslint",
+		CIProvider:     "github",
+		CIFeatures:     []string{"testing", "linting"},
+		AITools:        []string{},
+	}
+}
+
+// Apply fills in every answer project does not have yet, as if the remaining
+// screens were confirmed without changes.
+func (d WizardDefaults) Apply(project models.ProjectConfig) models.ProjectConfig {
+	if project.PackageManager == "" {
+		project.PackageManager = d.PackageManager
+	}
+	if project.Architecture == "" {
+		project.Architecture = d.Architecture
+	}
+	if len(project.Applications) == 0 {
+		project.Applications = []models.Application{{
+			ID:      generator.NewApplicationID(nil, d.AppType),
+			Name:    DefaultAppName(d.AppType),
+			Type:    d.AppType,
+			Options: DefaultAppOptions(d.AppType),
+		}}
+	}
+	// Single applications skip the shared packages screen
+	if project.Packages == nil && project.Architecture != models.ArchitectureSingle {
+		project.Packages = d.Packages
+	}
+	if project.DevTools.Linting == "" {
+		// The dev tools screen always enables these alongside the linting setup
+		project.DevTools = models.DevTools{
+			Linting:    d.Linting,
+			TypeScript: true,
+			Husky:      true,
+			LintStaged: true,
+		}
+	}
+	if project.Infrastructure == (models.Infrastructure{}) {
+		project.Infrastructure = d.Infrastructure
+	}
+	if project.CIPipeline.Provider == "" {
+		project.CIPipeline = models.CIPipeline{Provider: d.CIProvider, Features: d.CIFeatures}
+	}
+	if project.AITools.Editor == "" {
+		extensions := []string{}
+		for _, tool := range d.AITools {
+			toolExtensions, _ := AIToolExtensions(tool)
+			extensions = append(extensions, toolExtensions...)
+		}
+		project.AITools = models.AITools{Editor: strings.Join(d.AITools, ","), Extensions: extensions}
+	}
+	return project
+}
+
+// containsKey reports whether keys includes key
+func containsKey(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
@@ -21,7 +21,7 @@ type DevToolOption struct {
 }
 
 func NewDevToolsModel() DevToolsModel {
-	return DevToolsModel{
+	m := DevToolsModel{
 		options: []DevToolOption{
 			{"prettier-eslint", "Prettier + ESLint", "Traditional formatting and linting setup"},
 			{"biome", "Biome", "Fast, modern toolchain for web projects"},
@@ -31,6 +31,23 @@ func NewDevToolsModel() DevToolsModel {
 		cursor:   0,
 		selected: -1,
 	}
+	for i, option := range m.options {
+		if option.Key == Defaults().Linting {
+			m.selected = i
+		}
+	}
+	return m
+}
+
+// LintingTools returns the keys of the linting setups offered by the dev tools screen.
+func LintingTools() []string {
+	var keys []string
+	for _, option := range NewDevToolsModel().options {
+		if option.Key != "continue" {
+			keys = append(keys, option.Key)
+		}
+	}
+	return keys
 }
 
 func (m DevToolsModel) Init() tea.Cmd {
@@ -51,8 +68,8 @@ func (m DevToolsModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			}
 		case "enter":
 			if m.options[m.cursor].Key == "continue" {
-				// Continue with selected tool (default to first option if none selected)
-				selectedTool := "prettier-eslint" // default
+				// Continue with selected tool (the default one if none is selected)
+				selectedTool := Defaults().Linting
 				if m.selected >= 0 {
 					selectedTool = m.options[m.selected].Key
 				}
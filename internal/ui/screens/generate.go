@@ -1,23 +1,58 @@
 package screens
 
 import (
+	"context"
 	"fmt"
 	"strings"
-	"time"
 
+	"teapot/internal/errors"
+	"teapot/internal/generator"
+	"teapot/internal/models"
 	"teapot/internal/ui/components"
 	"teapot/internal/ui/styles"
 
+	"github.com/charmbracelet/bubbles/viewport"
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 )
 
+const (
+	// installLogWidth and installLogHeight size the install log pane
+	installLogWidth  = 72
+	installLogHeight = 10
+	// maxInstallLogLines is how much package manager output the log pane keeps
+	maxInstallLogLines = 1000
+)
+
 type GeneratingModel struct {
+	ctx         context.Context
+	engine      *generator.Engine
+	plan        *generator.Plan
+	project     models.ProjectConfig
+	targetDir   string
+	events      chan tea.Msg
 	progress    int
 	currentStep string
 	steps       []GenerationStep
 	completed   []bool
+	active      int
+	written     int
+	backupDir   string
 	done        bool
+	err         error
+	// installStep is the index of the dependency install, which runs after the plan's steps
+	installStep   int
+	installing    bool
+	installLog    []string
+	logView       viewport.Model
+	installErr    *errors.TeapotError
+	cancelInstall context.CancelFunc
+
+	// cancelGenerate stops the engine; it is nil once the engine has finished
+	cancelGenerate context.CancelFunc
+	// quitting is set when esc was pressed while the engine or the install was
+	// still running; the screen quits once it has stopped
+	quitting bool
 }
 
 type GenerationStep struct {
@@ -25,72 +60,225 @@ type GenerationStep struct {
 	Description string
 }
 
-func NewGeneratingModel() GeneratingModel {
-	steps := []GenerationStep{
-		{"Base project initialized", "Creating project structure"},
-		{"Monorepo workspace configured", "Setting up workspace"},
-		{"Apps scaffolded", "Generating applications"},
-		{"Packages created", "Setting up shared packages"},
-		{"Installing dependencies", "Running package manager"},
-		{"Setting up Git hooks", "Configuring development tools"},
-		{"Configuring CI/CD", "Setting up automation"},
+func NewGeneratingModel(project models.ProjectConfig, targetDir string, policy generator.ConflictPolicy) GeneratingModel {
+	engine := generator.NewEngine(project, targetDir)
+	engine.SetConflictPolicy(policy)
+	plan, err := engine.Plan()
+
+	var steps []GenerationStep
+	currentStep := "Creating project structure..."
+	if plan != nil {
+		for _, step := range plan.Steps {
+			steps = append(steps, GenerationStep{Name: step.Name, Description: step.Description})
+		}
+		if len(steps) > 0 {
+			currentStep = steps[0].Description
+		}
+	}
+	installStep := len(steps)
+	if plan != nil {
+		steps = append(steps, GenerationStep{
+			Name:        "Dependencies installed",
+			Description: "Installing dependencies with " + generator.InstallCommand(generator.ProjectPackageManager(project)),
+		})
 	}
 
-	return GeneratingModel{
+	model := GeneratingModel{
+		engine:      engine,
+		plan:        plan,
+		project:     project,
+		targetDir:   targetDir,
+		installStep: installStep,
+		logView:     viewport.New(installLogWidth, installLogHeight),
+		events:      make(chan tea.Msg),
 		progress:    0,
-		currentStep: "Creating project structure...",
+		currentStep: currentStep,
 		steps:       steps,
 		completed:   make([]bool, len(steps)),
+		active:      0,
 		done:        false,
+		err:         err,
 	}
+	if err == nil && plan != nil {
+		// Init runs the engine with this context; esc cancels it
+		model.ctx, model.cancelGenerate = context.WithCancel(context.Background())
+	}
+	return model
 }
 
 func (m GeneratingModel) Init() tea.Cmd {
-	return tea.Tick(time.Millisecond*200, func(t time.Time) tea.Msg {
-		return ProgressMsg{}
-	})
+	if m.err != nil || m.plan == nil {
+		return nil
+	}
+
+	return func() tea.Msg {
+		// Run the engine in the background and forward its events to the UI
+		go func() {
+			err := m.engine.Generate(m.ctx, m.plan, func(event generator.Event) {
+				m.events <- GenerationEventMsg{Event: event}
+			})
+			m.events <- GenerationFinishedMsg{Err: err}
+		}()
+
+		return <-m.events
+	}
+}
+
+// waitForGeneration returns a command that blocks until the next generation event
+func waitForGeneration(events chan tea.Msg) tea.Cmd {
+	return func() tea.Msg {
+		return <-events
+	}
+}
+
+// startInstall runs the package manager in the generated project, streaming its output to the log pane
+func (m GeneratingModel) startInstall() (GeneratingModel, tea.Cmd) {
+	ctx, cancel := context.WithCancel(context.Background())
+	m.cancelInstall = cancel
+	m.installing = true
+	m.installErr = nil
+	m.active = m.installStep
+	m.currentStep = m.steps[m.installStep].Description
+	m.appendLog("$ " + generator.InstallCommand(generator.ProjectPackageManager(m.project)))
+
+	events, project, dir := m.events, m.project, m.targetDir
+	return m, func() tea.Msg {
+		go func() {
+			err := generator.InstallDependencies(ctx, project, dir, func(line string) {
+				events <- InstallOutputMsg{Line: line}
+			})
+			events <- InstallFinishedMsg{Err: err}
+		}()
+
+		return <-events
+	}
+}
+
+// appendLog adds a line to the install log. The pane keeps following the output
+// unless it was scrolled up.
+func (m *GeneratingModel) appendLog(line string) {
+	if runes := []rune(line); len(runes) > installLogWidth {
+		line = string(runes[:installLogWidth-1]) + "…"
+	}
+
+	follow := m.logView.AtBottom()
+	m.installLog = append(m.installLog, line)
+	if len(m.installLog) > maxInstallLogLines {
+		m.installLog = m.installLog[len(m.installLog)-maxInstallLogLines:]
+	}
+	m.logView.SetContent(strings.Join(m.installLog, "\n"))
+	if follow {
+		m.logView.GotoBottom()
+	}
+}
+
+// finish marks the project as ready and moves on to the completion screen
+func (m GeneratingModel) finish() (GeneratingModel, tea.Cmd) {
+	m.done = true
+	m.progress = 100
+	m.currentStep = "Project generation complete!"
+	return m, func() tea.Msg {
+		return GenerationCompleteMsg{}
+	}
 }
 
 func (m GeneratingModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
 		switch msg.String() {
-		case "esc":
-			return m, tea.Quit
+		case "esc", "ctrl+c":
+			// Wait for a running engine or install to stop so the staging
+			// directory is cleaned up and nothing is left writing to the project
+			switch {
+			case m.quitting:
+				return m, nil
+			case m.cancelGenerate != nil:
+				m.cancelGenerate()
+			case m.cancelInstall != nil:
+				m.cancelInstall()
+			default:
+				return m, tea.Quit
+			}
+			m.quitting = true
+			m.currentStep = "Cancelling..."
+			return m, nil
 		case "enter":
 			if m.done {
 				return m, func() tea.Msg {
 					return GenerationCompleteMsg{}
 				}
 			}
+		case "r":
+			if m.installErr != nil {
+				m.appendLog("")
+				return m.startInstall()
+			}
+		case "s":
+			if m.installErr != nil {
+				// The project is complete; dependencies can be installed by hand later
+				m.installErr = nil
+				return m.finish()
+			}
+		}
+		if len(m.installLog) > 0 {
+			var cmd tea.Cmd
+			m.logView, cmd = m.logView.Update(msg)
+			return m, cmd
 		}
 
-	case ProgressMsg:
-		if !m.done {
-			if m.progress < 100 {
-				m.progress += 2
-				stepIndex := (m.progress * len(m.steps)) / 100
-				if stepIndex < len(m.steps) {
-					m.currentStep = m.steps[stepIndex].Description
-					if stepIndex > 0 {
-						m.completed[stepIndex-1] = true
-					}
-				}
+	case GenerationEventMsg:
+		event := msg.Event
+		switch event.Type {
+		case generator.EventStepStarted:
+			m.active = event.Step
+			m.currentStep = m.steps[event.Step].Description
+		case generator.EventFileWritten, generator.EventFileSkipped:
+			m.written++
+			if total := m.plan.FileCount(); total > 0 {
+				m.progress = (m.written * 100) / total
 			}
+		case generator.EventStepFinished:
+			m.completed[event.Step] = true
+		case generator.EventBackupCreated:
+			m.backupDir = event.Path
+		}
+		return m, waitForGeneration(m.events)
 
-			if m.progress >= 100 {
-				m.done = true
-				m.completed[len(m.completed)-1] = true
-				m.currentStep = "Project generation complete!"
-				return m, func() tea.Msg {
-					return GenerationCompleteMsg{}
-				}
-			}
+	case GenerationFinishedMsg:
+		if m.cancelGenerate != nil {
+			m.cancelGenerate()
+			m.cancelGenerate = nil
+		}
+		if m.quitting {
+			return m, tea.Quit
+		}
+		if msg.Err != nil {
+			m.err = msg.Err
+			m.currentStep = "Project generation failed"
+			return m, nil
+		}
 
-			return m, tea.Tick(time.Millisecond*100, func(t time.Time) tea.Msg {
-				return ProgressMsg{}
-			})
+		m.progress = 100
+		return m.startInstall()
+
+	case InstallOutputMsg:
+		m.appendLog(msg.Line)
+		return m, waitForGeneration(m.events)
+
+	case InstallFinishedMsg:
+		m.installing = false
+		m.cancelInstall = nil
+		if m.quitting {
+			return m, tea.Quit
 		}
+		if msg.Err != nil {
+			m.installErr = errors.AsTeapotError(msg.Err, errors.ErrorTypeSystem)
+			m.currentStep = "Installing dependencies failed"
+			return m, nil
+		}
+
+		m.completed[m.installStep] = true
+		return m.finish()
 	}
 
 	return m, nil
@@ -101,16 +289,23 @@ func (m GeneratingModel) View() string {
 
 	progressBar := m.renderProgressBar()
 	statusText := fmt.Sprintf("%s %d%%", m.currentStep, m.progress)
+	if m.active == m.installStep && !m.done {
+		// The progress bar counts files, which are all written by now
+		statusText = m.currentStep
+	}
 
 	var stepsList strings.Builder
 	for i, step := range m.steps {
 		icon := "○"
 		style := styles.UnselectedStyle
-		
+
 		if m.completed[i] {
 			icon = "✓"
 			style = styles.CheckedStyle
-		} else if i == (m.progress*len(m.steps))/100 {
+		} else if i == m.active && (m.err != nil || m.installErr != nil) {
+			icon = "✗"
+			style = lipgloss.NewStyle().Foreground(styles.ColorError)
+		} else if i == m.active {
 			icon = "⟳"
 			style = styles.SelectedStyle
 		}
@@ -120,7 +315,41 @@ func (m GeneratingModel) View() string {
 
 	content := subtitle + "\n\n" + progressBar + "\n" + statusText + "\n\n" + stepsList.String()
 
-	if m.done {
+	if m.backupDir != "" {
+		content += "\n" + lipgloss.NewStyle().
+			Foreground(styles.ColorTextMuted).
+			Render("Previous files moved to "+m.backupDir) + "\n"
+	}
+
+	if len(m.installLog) > 0 {
+		logPane := lipgloss.NewStyle().
+			Border(lipgloss.RoundedBorder()).
+			BorderForeground(styles.ColorBorderPrimary).
+			Padding(0, 1).
+			Render(m.logView.View())
+		content += "\n" + logPane + "\n"
+	}
+
+	if m.err != nil {
+		errorMsg := lipgloss.NewStyle().
+			Foreground(styles.ColorError).
+			Margin(1, 0, 0, 0).
+			Render("⚠️  " + m.err.Error())
+		content += errorMsg + "\n\n" + components.RenderHelp("esc: exit")
+	} else if m.installErr != nil {
+		errorMsg := lipgloss.NewStyle().
+			Foreground(styles.ColorError).
+			Margin(1, 0, 0, 0).
+			Render("⚠️  " + m.installErr.Message)
+		hint := lipgloss.NewStyle().
+			Foreground(styles.ColorTextMuted).
+			Render("The project is ready; skip to run " + generator.InstallCommand(generator.ProjectPackageManager(m.project)) + " yourself later.")
+		content += errorMsg + "\n" + hint + "\n\n" + components.RenderHelp("r: retry • s: skip • ↑/↓: scroll log • esc: exit")
+	} else if m.quitting {
+		content += "\n" + components.RenderHelp("cancelling...")
+	} else if m.installing {
+		content += "\n" + components.RenderHelp("↑/↓: scroll log • esc: cancel")
+	} else if m.done {
 		content += "\n" + components.RenderHelp("enter: continue")
 	} else {
 		content += "\n" + components.RenderHelp("esc: cancel")
@@ -132,7 +361,7 @@ func (m GeneratingModel) View() string {
 func (m GeneratingModel) renderProgressBar() string {
 	width := 31
 	filled := (m.progress * width) / 100
-	
+
 	var bar strings.Builder
 	bar.WriteString("┌")
 	for i := 0; i < width; i++ {
@@ -149,6 +378,25 @@ func (m GeneratingModel) renderProgressBar() string {
 		Render(bar.String())
 }
 
-type ProgressMsg struct{}
+// GenerationEventMsg carries a progress event from the generator engine
+type GenerationEventMsg struct {
+	Event generator.Event
+}
+
+// GenerationFinishedMsg is sent when the generator engine has stopped
+type GenerationFinishedMsg struct {
+	Err error
+}
+
+type GenerationCompleteMsg struct{}
+
+// InstallOutputMsg carries a line the package manager printed while installing dependencies
+type InstallOutputMsg struct {
+	Line string
+}
 
-type GenerationCompleteMsg struct{}
\ No newline at end of file
+// InstallFinishedMsg is sent when the package manager has exited. Err is a
+// recoverable TeapotError when the install failed.
+type InstallFinishedMsg struct {
+	Err error
+}
@@ -22,7 +22,7 @@ type InfraOption struct {
 }
 
 func NewInfrastructureModel() InfrastructureModel {
-	return InfrastructureModel{
+	m := InfrastructureModel{
 		options: []InfraOption{
 			{"docker", "Docker", "Containerize your applications", false, false},
 			{"docker-compose", "Docker Compose", "Multi-container development setup", false, false},
@@ -32,6 +32,18 @@ func NewInfrastructureModel() InfrastructureModel {
 		},
 		cursor: 0,
 	}
+
+	defaults := Defaults().Infrastructure
+	selected := map[string]bool{
+		"docker":         defaults.Docker,
+		"docker-compose": defaults.DockerCompose,
+		"pulumi":         defaults.Pulumi,
+		"terraform":      defaults.Terraform,
+	}
+	for i, option := range m.options {
+		m.options[i].Selected = selected[option.Key]
+	}
+	return m
 }
 
 func (m InfrastructureModel) Init() tea.Cmd {
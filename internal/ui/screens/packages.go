@@ -0,0 +1,167 @@
+package screens
+
+import (
+	"teapot/internal/models"
+	"teapot/internal/ui/components"
+	"teapot/internal/ui/styles"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type PackagesModel struct {
+	options []PackageOption
+	cursor  int
+}
+
+type PackageOption struct {
+	Type        models.PackageType
+	Description string
+	Selected    bool
+	IsContinue  bool // Special flag for continue option
+}
+
+func NewPackagesModel() PackagesModel {
+	m := PackagesModel{
+		options: []PackageOption{
+			{models.PackageUI, "Shared React components for your web apps", false, false},
+			{models.PackageTSConfig, "Base TypeScript configs extended by every app", false, false},
+			{models.PackageESLintConfig, "One ESLint flat config for the whole repo", false, false},
+			{models.PackagePrettierConfig, "Shared formatting rules", false, false},
+			{models.PackageDatabase, "Prisma schema and database client", false, false},
+			{models.PackageAuth, "Session and token helpers", false, false},
+			{models.PackageUtils, "Common helper functions", false, false},
+			{models.PackageAPIClient, "Typed fetch client for your APIs", false, false},
+			{"continue", "Proceed with selected packages", false, true},
+		},
+		cursor: 0,
+	}
+	for i, option := range m.options {
+		for _, pkg := range Defaults().Packages {
+			if !option.IsContinue && option.Type == pkg {
+				m.options[i].Selected = true
+			}
+		}
+	}
+	return m
+}
+
+// SharedPackages returns the keys of the shared packages offered by the packages screen.
+func SharedPackages() []string {
+	var keys []string
+	for _, option := range NewPackagesModel().options {
+		if !option.IsContinue {
+			keys = append(keys, string(option.Type))
+		}
+	}
+	return keys
+}
+
+func (m PackagesModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m PackagesModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "j", "down":
+			if m.cursor < len(m.options)-1 {
+				m.cursor++
+			}
+		case "k", "up":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case " ":
+			// Space only works on non-continue options
+			if !m.options[m.cursor].IsContinue {
+				m.options[m.cursor].Selected = !m.options[m.cursor].Selected
+			}
+		case "enter":
+			// Enter either selects current item or continues if on continue option
+			if m.options[m.cursor].IsContinue {
+				// An empty selection means no shared packages, not the defaults
+				selected := []models.PackageType{}
+				for _, option := range m.options {
+					if !option.IsContinue && option.Selected {
+						selected = append(selected, option.Type)
+					}
+				}
+
+				return m, func() tea.Msg {
+					return PackagesSelectedMsg{
+						Packages: selected,
+					}
+				}
+			} else {
+				// Select current item
+				m.options[m.cursor].Selected = !m.options[m.cursor].Selected
+				return m, nil
+			}
+		case "s":
+			// Skip shared packages
+			return m, func() tea.Msg {
+				return PackagesSelectedMsg{Packages: []models.PackageType{}}
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m PackagesModel) View() string {
+	subtitle := components.RenderSubtitle("Shared Packages")
+
+	var choices string
+	for i, option := range m.options {
+		cursor := " "
+		if m.cursor == i {
+			cursor = ">"
+		}
+
+		var checked string
+		var name string
+		var optionStyle lipgloss.Style
+
+		if option.IsContinue {
+			checked = "→"
+			name = "Continue"
+		} else {
+			checked = "☐"
+			if option.Selected {
+				checked = "☑"
+			}
+			name = models.PackageTypeNames[option.Type] + " (packages/" + string(option.Type) + ")"
+		}
+
+		if m.cursor == i {
+			optionStyle = styles.FocusedStyle
+		} else if option.Selected {
+			optionStyle = styles.CheckedStyle
+		} else {
+			optionStyle = styles.UnselectedStyle
+		}
+
+		choiceText := cursor + " " + checked + " " + name
+		choice := optionStyle.Render(choiceText)
+
+		description := lipgloss.NewStyle().
+			Foreground(styles.ColorTextMuted).
+			Margin(0, 0, 0, 4).
+			Render(option.Description)
+
+		choices += choice + "\n" + description + "\n"
+	}
+
+	skipNote := lipgloss.NewStyle().
+		Foreground(styles.ColorWarning).
+		Bold(true).
+		Margin(1, 0, 0, 0).
+		Render("Press 's' to skip shared packages")
+
+	return subtitle + "\n\n" + choices + skipNote
+}
+
+type PackagesSelectedMsg struct {
+	Packages []models.PackageType
+}
@@ -2,7 +2,9 @@ package screens
 
 import (
 	"fmt"
+	"strings"
 	
+	"teapot/internal/models"
 	"teapot/internal/ui/components"
 	"teapot/internal/ui/styles"
 	"teapot/internal/validation"
@@ -12,10 +14,28 @@ import (
 	"github.com/charmbracelet/lipgloss"
 )
 
+// packageManagerOrder lists the package managers in the order the screen offers them
+var packageManagerOrder = []models.PackageManagerType{
+	models.PackageManagerBun,
+	models.PackageManagerPnpm,
+	models.PackageManagerNpm,
+	models.PackageManagerYarn,
+}
+
+// PackageManagers returns the keys of the package managers offered by the project setup screen.
+func PackageManagers() []string {
+	keys := make([]string, len(packageManagerOrder))
+	for i, pm := range packageManagerOrder {
+		keys[i] = string(pm)
+	}
+	return keys
+}
+
 type ProjectSetupModel struct {
 	nameInput    textinput.Model
 	descInput    textinput.Model
-	focusedField int // 0 = name, 1 = description
+	focusedField int // 0 = name, 1 = description, 2 = package manager
+	pmIndex      int
 	validationErr error
 }
 
@@ -33,12 +53,54 @@ func NewProjectSetupModel() ProjectSetupModel {
 	descInput.CharLimit = 200
 	descInput.Width = 35
 
-	return ProjectSetupModel{
+	m := ProjectSetupModel{
 		nameInput:     nameInput,
 		descInput:     descInput,
 		focusedField:  0,
 		validationErr: nil,
 	}
+	m.selectPackageManager(Defaults().PackageManager)
+	return m
+}
+
+// NewPrefilledProjectSetupModel creates the project setup screen with answers given on the
+// command line. When a name is provided the description field is focused.
+func NewPrefilledProjectSetupModel(name, description string, packageManager models.PackageManagerType) ProjectSetupModel {
+	m := NewProjectSetupModel()
+	m.nameInput.SetValue(name)
+	m.descInput.SetValue(description)
+	m.selectPackageManager(packageManager)
+
+	if name != "" {
+		m.nameInput.Blur()
+		m.descInput.Focus()
+		m.focusedField = 1
+	}
+
+	return m
+}
+
+// selectPackageManager selects a package manager, keeping the current selection for unknown ones
+func (m *ProjectSetupModel) selectPackageManager(pm models.PackageManagerType) {
+	for i, option := range packageManagerOrder {
+		if option == pm {
+			m.pmIndex = i
+		}
+	}
+}
+
+// focus moves the focus to a field: 0 = name, 1 = description, 2 = package manager
+func (m *ProjectSetupModel) focus(field int) {
+	m.nameInput.Blur()
+	m.descInput.Blur()
+	switch field {
+	case 0:
+		m.nameInput.Focus()
+	case 1:
+		m.descInput.Focus()
+	}
+	m.focusedField = field
+	m.validationErr = nil
 }
 
 func (m ProjectSetupModel) Init() tea.Cmd {
@@ -67,50 +129,50 @@ func (m ProjectSetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				}
 				
 				// If name is valid, move to description field
-				m.nameInput.Blur()
-				m.descInput.Focus()
-				m.focusedField = 1
-				m.validationErr = nil
+				m.focus(1)
 				return m, nil
-			} else {
-				// Validate description and complete setup
-				description := m.descInput.Value()
-				if err := validation.ValidateProjectDescription(description); err != nil {
-					m.validationErr = err
-					return m, nil
-				}
-				
-				// Both fields are valid, proceed
-				m.validationErr = nil
-				return m, func() tea.Msg {
-					return ProjectSetupCompleteMsg{
-						ProjectName: m.nameInput.Value(),
-						Description: description,
-					}
-				}
 			}
-		case "tab":
-			if m.focusedField == 0 {
-				m.nameInput.Blur()
-				m.descInput.Focus()
-				m.focusedField = 1
-			} else {
-				m.descInput.Blur()
-				m.nameInput.Focus()
-				m.focusedField = 0
+
+			// Validate description before continuing
+			description := m.descInput.Value()
+			if err := validation.ValidateProjectDescription(description); err != nil {
+				m.validationErr = err
+				return m, nil
 			}
-			m.validationErr = nil
-		case "shift+tab":
 			if m.focusedField == 1 {
-				m.descInput.Blur()
-				m.nameInput.Focus()
-				m.focusedField = 0
-			} else {
-				m.nameInput.Blur()
-				m.descInput.Focus()
-				m.focusedField = 1
+				m.focus(2)
+				return m, nil
 			}
+
+			// The name is checked again in case it was edited after moving on
+			if err := validation.ValidateProjectName(m.nameInput.Value()); err != nil {
+				m.focus(0)
+				m.validationErr = err
+				return m, nil
+			}
+
+			// All fields are valid, proceed
 			m.validationErr = nil
+			return m, func() tea.Msg {
+				return ProjectSetupCompleteMsg{
+					ProjectName:    m.nameInput.Value(),
+					Description:    description,
+					PackageManager: packageManagerOrder[m.pmIndex],
+				}
+			}
+		case "tab":
+			m.focus((m.focusedField + 1) % 3)
+		case "shift+tab":
+			m.focus((m.focusedField + 2) % 3)
+		case "left", "right":
+			if m.focusedField == 2 {
+				step := 1
+				if msg.String() == "left" {
+					step = len(packageManagerOrder) - 1
+				}
+				m.pmIndex = (m.pmIndex + step) % len(packageManagerOrder)
+				return m, nil
+			}
 		}
 	}
 
@@ -125,7 +187,7 @@ func (m ProjectSetupModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	if m.focusedField == 0 {
 		m.nameInput, cmd = m.nameInput.Update(msg)
 		cmds = append(cmds, cmd)
-	} else {
+	} else if m.focusedField == 1 {
 		m.descInput, cmd = m.descInput.Update(msg)
 		cmds = append(cmds, cmd)
 	}
@@ -176,6 +238,33 @@ func (m ProjectSetupModel) View() string {
 
 	descBox := descInputStyle.Render(m.descInput.View())
 
+	// Package manager field
+	pmLabel := lipgloss.NewStyle().
+		Foreground(styles.ColorTextPrimary).
+		Bold(true).
+		Margin(1, 0, 0, 0).
+		Render("📦 Package manager:")
+
+	pmInputStyle := lipgloss.NewStyle().
+		Border(lipgloss.RoundedBorder()).
+		BorderForeground(styles.ColorBorderPrimary).
+		Padding(0, 1).
+		Margin(1, 0, 0, 0)
+
+	if m.focusedField == 2 {
+		pmInputStyle = pmInputStyle.BorderForeground(styles.ColorBorderAccent)
+	}
+
+	pmOptions := make([]string, len(packageManagerOrder))
+	for i, pm := range packageManagerOrder {
+		if i == m.pmIndex {
+			pmOptions[i] = styles.CheckedStyle.Render("● " + models.PackageManagerNames[pm])
+		} else {
+			pmOptions[i] = styles.UncheckedStyle.Render("○ " + models.PackageManagerNames[pm])
+		}
+	}
+	pmBox := pmInputStyle.Render(strings.Join(pmOptions, "  "))
+
 	// Error message if validation fails
 	var errorMsg string
 	if m.validationErr != nil {
@@ -187,16 +276,16 @@ func (m ProjectSetupModel) View() string {
 
 	// Instructions
 	var instructions string
-	if m.focusedField == 0 {
+	if m.focusedField == 2 {
 		instructions = lipgloss.NewStyle().
 			Foreground(styles.ColorTextMuted).
 			Margin(1, 0, 0, 0).
-			Render("Enter: next field • Tab: switch fields")
+			Render("←/→: choose • Enter: continue • Tab: switch fields")
 	} else {
 		instructions = lipgloss.NewStyle().
 			Foreground(styles.ColorTextMuted).
 			Margin(1, 0, 0, 0).
-			Render("Enter: continue • Tab: switch fields")
+			Render("Enter: next field • Tab: switch fields")
 	}
 
 	// Character count helpers
@@ -213,6 +302,7 @@ func (m ProjectSetupModel) View() string {
 	content := subtitle + "\n\n" + 
 		      nameLabel + nameCount + "\n" + nameBox + "\n\n" + 
 		      descLabel + descCount + "\n" + descBox + "\n\n" + 
+		      pmLabel + "\n" + pmBox + "\n\n" + 
 		      instructions
 
 	if errorMsg != "" {
@@ -223,6 +313,7 @@ func (m ProjectSetupModel) View() string {
 }
 
 type ProjectSetupCompleteMsg struct {
-	ProjectName string
-	Description string
+	ProjectName    string
+	Description    string
+	PackageManager models.PackageManagerType
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package screens
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 
@@ -16,10 +17,15 @@ import (
 type YAMLPreviewModel struct {
 	project    models.ProjectConfig
 	yamlContent string
+	filePlan    string
+	showFiles   bool
 	scrollOffset int
 	maxLines    int
 	options     []string
 	cursor      int
+	// confirmOverwrite is set while asking whether to replace an existing teapot.yml
+	confirmOverwrite bool
+	status      string
 }
 
 func NewYAMLPreviewModel(project models.ProjectConfig) YAMLPreviewModel {
@@ -28,18 +34,36 @@ func NewYAMLPreviewModel(project models.ProjectConfig) YAMLPreviewModel {
 		yamlContent = fmt.Sprintf("Error generating YAML: %v", err)
 	}
 
+	// The file plan is computed in memory only; nothing is written until generation
+	var filePlan string
+	if plan, err := generator.BuildPlan(project); err != nil {
+		filePlan = fmt.Sprintf("Error planning files: %v", err)
+	} else {
+		filePlan = generator.FormatPlanForDisplay(plan)
+	}
+
 	return YAMLPreviewModel{
 		project:     project,
 		yamlContent: yamlContent,
+		filePlan:    filePlan,
 		scrollOffset: 0,
 		maxLines:    20, // Will be adjusted based on terminal height
 		options: []string{
 			"Save teapot.yml",
+			"Preview files",
 			"Continue to Generation",
 			"Back to Edit",
 		},
-		cursor: 1, // Default to "Continue to Generation"
+		cursor: 2, // Default to "Continue to Generation"
+	}
+}
+
+// content returns the text shown in the preview box
+func (m YAMLPreviewModel) content() string {
+	if m.showFiles {
+		return m.filePlan
 	}
+	return m.yamlContent
 }
 
 func (m YAMLPreviewModel) Init() tea.Cmd {
@@ -48,7 +72,32 @@ func (m YAMLPreviewModel) Init() tea.Cmd {
 
 func (m YAMLPreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 	switch msg := msg.(type) {
+	case YAMLSavedMsg:
+		switch {
+		case errors.Is(msg.Err, generator.ErrTeapotYAMLExists):
+			m.confirmOverwrite = true
+			m.status = ""
+		case msg.Err != nil:
+			m.status = "✗ " + msg.Err.Error()
+		default:
+			m.status = "✓ Saved teapot.yml"
+		}
+
 	case tea.KeyMsg:
+		if m.confirmOverwrite {
+			switch msg.String() {
+			case "y":
+				m.confirmOverwrite = false
+				return m, func() tea.Msg {
+					return YAMLSaveMsg{Overwrite: true}
+				}
+			case "n":
+				m.confirmOverwrite = false
+				m.status = "Kept the existing teapot.yml"
+			}
+			return m, nil
+		}
+
 		switch msg.String() {
 		case "j", "down":
 			if m.cursor < len(m.options)-1 {
@@ -59,13 +108,13 @@ func (m YAMLPreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 				m.cursor--
 			}
 		case "ctrl+j":
-			// Scroll YAML content down
-			yamlLines := strings.Split(m.yamlContent, "\n")
+			// Scroll preview content down
+			yamlLines := strings.Split(m.content(), "\n")
 			if m.scrollOffset < len(yamlLines)-m.maxLines {
 				m.scrollOffset++
 			}
 		case "ctrl+k":
-			// Scroll YAML content up
+			// Scroll preview content up
 			if m.scrollOffset > 0 {
 				m.scrollOffset--
 			}
@@ -73,13 +122,22 @@ func (m YAMLPreviewModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
 			switch m.cursor {
 			case 0: // Save teapot.yml
 				return m, func() tea.Msg {
-					return YAMLSaveMsg{Project: m.project}
+					return YAMLSaveMsg{}
+				}
+			case 1: // Toggle between teapot.yml and the file plan
+				m.showFiles = !m.showFiles
+				m.scrollOffset = 0
+				if m.showFiles {
+					m.options[1] = "Preview teapot.yml"
+				} else {
+					m.options[1] = "Preview files"
 				}
-			case 1: // Continue to Generation
+				return m, nil
+			case 2: // Continue to Generation
 				return m, func() tea.Msg {
-					return YAMLContinueMsg{Project: m.project}
+					return YAMLContinueMsg{}
 				}
-			case 2: // Back to Edit
+			case 3: // Back to Edit
 				return m, func() tea.Msg {
 					return YAMLBackMsg{}
 				}
@@ -99,8 +157,8 @@ func (m YAMLPreviewModel) View() string {
 		Margin(1, 0, 0, 0).
 		Render(fmt.Sprintf("✓ Project: %s", m.project.Name))
 
-	// YAML content box
-	yamlLines := strings.Split(m.yamlContent, "\n")
+	// Preview content box
+	yamlLines := strings.Split(m.content(), "\n")
 	displayLines := yamlLines
 	if len(yamlLines) > m.maxLines {
 		end := m.scrollOffset + m.maxLines
@@ -116,7 +174,7 @@ func (m YAMLPreviewModel) View() string {
 		Background(styles.ColorBgSecondary).
 		Foreground(styles.ColorTextSecondary).
 		Padding(1, 2).
-		Width(70).
+		Width(m.boxWidth()).
 		Height(m.maxLines + 2).
 		Margin(1, 0).
 		Render(strings.Join(displayLines, "\n"))
@@ -133,6 +191,18 @@ func (m YAMLPreviewModel) View() string {
 				len(yamlLines)))
 	}
 
+	// Save confirmation or result
+	if m.confirmOverwrite {
+		scrollInfo += "\n" + lipgloss.NewStyle().
+			Foreground(styles.ColorError).
+			Bold(true).
+			Render("teapot.yml already exists in this directory. Overwrite it? (y/n)")
+	} else if m.status != "" {
+		scrollInfo += "\n" + lipgloss.NewStyle().
+			Foreground(styles.ColorSuccess).
+			Render(m.status)
+	}
+
 	// Options
 	var choices string
 	for i, option := range m.options {
@@ -143,9 +213,11 @@ func (m YAMLPreviewModel) View() string {
 		switch i {
 		case 0: // Save
 			icon = "💾"
-		case 1: // Continue
+		case 1: // Preview
+			icon = "🔍"
+		case 2: // Continue
 			icon = "🚀"
-		case 2: // Back
+		case 3: // Back
 			icon = "←"
 		}
 
@@ -161,7 +233,7 @@ func (m YAMLPreviewModel) View() string {
 	}
 
 	// Help text
-	helpText := components.RenderHelp("↑↓: navigate • enter: select • ctrl+j/k: scroll YAML • backspace: back")
+	helpText := components.RenderHelp("↑↓: navigate • enter: select • ctrl+j/k: scroll preview • backspace: back")
 
 	return subtitle + "\n\n" + 
 		   summary + "\n" + 
@@ -171,15 +243,28 @@ func (m YAMLPreviewModel) View() string {
 		   helpText
 }
 
-// Message types for YAML preview actions
+// boxWidth returns the preview box width; the file plan needs room for its columns
+func (m YAMLPreviewModel) boxWidth() int {
+	if m.showFiles {
+		return 100
+	}
+	return 70
+}
+
+// Message types for YAML preview actions. They carry no project: the app
+// saves and generates the answers it holds, never a copy kept by the preview.
 type YAMLSaveMsg struct {
-	Project models.ProjectConfig
+	// Overwrite replaces an existing teapot.yml once the user confirmed it
+	Overwrite bool
 }
 
-type YAMLContinueMsg struct {
-	Project models.ProjectConfig
+// YAMLSavedMsg reports the result of saving teapot.yml back to the preview
+type YAMLSavedMsg struct {
+	Err error
 }
 
+type YAMLContinueMsg struct{}
+
 type YAMLBackMsg struct{}
 
 // Helper function
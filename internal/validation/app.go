@@ -0,0 +1,64 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"teapot/internal/errors"
+)
+
+// AppNamePattern is the regular expression every application name matches.
+// Application names become folder names below apps/, so they are limited to
+// ASCII letters, digits, hyphens and underscores.
+const AppNamePattern = `^[A-Za-z0-9_-]+$`
+
+// ValidateAppName validates an application name. It checks for:
+// - An empty name
+// - Path traversal attempts
+// - Valid character set (ASCII letters, digits, hyphens, underscores)
+func ValidateAppName(name string) error {
+	if name == "" {
+		return errors.NewValidationError("application name cannot be empty", nil)
+	}
+
+	// Check for path traversal attempts
+	if strings.Contains(name, "..") || strings.Contains(name, "/") || strings.Contains(name, "\\") {
+		return errors.NewValidationError("application name cannot contain path separators", nil)
+	}
+
+	for i, char := range name {
+		if !IsValidAppNameChar(char) {
+			return errors.NewValidationError(fmt.Sprintf("invalid character '%c' at position %d", char, i+1), nil)
+		}
+	}
+
+	return nil
+}
+
+// IsValidAppNameChar checks if a character is valid in application names.
+func IsValidAppNameChar(char rune) bool {
+	return (char >= 'a' && char <= 'z') ||
+		(char >= 'A' && char <= 'Z') ||
+		(char >= '0' && char <= '9') ||
+		char == '-' || char == '_'
+}
+
+// SanitizeAppName turns a name into a valid application name by replacing
+// spaces and path separators with hyphens and dropping other invalid
+// characters. It returns an empty string when nothing valid is left.
+func SanitizeAppName(name string) string {
+	name = strings.NewReplacer(" ", "-", "/", "-", "\\", "-").Replace(name)
+
+	var result strings.Builder
+	for _, char := range name {
+		if IsValidAppNameChar(char) {
+			result.WriteRune(char)
+		}
+	}
+
+	sanitized := result.String()
+	for strings.Contains(sanitized, "--") {
+		sanitized = strings.ReplaceAll(sanitized, "--", "-")
+	}
+	return strings.Trim(sanitized, "-_")
+}
@@ -0,0 +1,62 @@
+package validation
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestValidateAppName(t *testing.T) {
+	tests := []struct {
+		name          string
+		appName       string
+		errorContains string
+	}{
+		{"simple name", "web", ""},
+		{"mixed", "Admin_v2-app", ""},
+		{"empty", "", "cannot be empty"},
+		{"parent traversal", "../../escape", "path separators"},
+		{"nested folder", "a/b", "path separators"},
+		{"backslash", `a\b`, "path separators"},
+		{"dot", "my.app", "invalid character '.' at position 3"},
+		{"space", "my app", "invalid character ' ' at position 3"},
+		{"unicode", "café", "invalid character"},
+	}
+
+	pattern := regexp.MustCompile(AppNamePattern)
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAppName(tt.appName)
+			if tt.errorContains == "" && err != nil {
+				t.Errorf("Expected no error for %q, got: %v", tt.appName, err)
+			}
+			if tt.errorContains != "" && (err == nil || !strings.Contains(err.Error(), tt.errorContains)) {
+				t.Errorf("Expected an error containing %q for %q, got: %v", tt.errorContains, tt.appName, err)
+			}
+			if matches := pattern.MatchString(tt.appName); matches != (err == nil) {
+				t.Errorf("Expected AppNamePattern to agree with ValidateAppName for %q", tt.appName)
+			}
+		})
+	}
+}
+
+func TestSanitizeAppName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"web", "web"},
+		{"../../escape", "escape"},
+		{"a/b", "a-b"},
+		{`a\b`, "a-b"},
+		{"my app", "my-app"},
+		{"my.app", "myapp"},
+		{"../..", ""},
+	}
+
+	for _, tt := range tests {
+		if result := SanitizeAppName(tt.input); result != tt.expected {
+			t.Errorf("SanitizeAppName(%q): expected %q, got %q", tt.input, tt.expected, result)
+		}
+	}
+}
@@ -4,29 +4,26 @@ import (
 	"fmt"
 	"os"
 
-	"teapot/internal/ui"
+	"teapot/internal/cli"
 
 	tea "github.com/charmbracelet/bubbletea"
 )
 
 func main() {
+	os.Exit(run())
+}
+
+// run executes the command line and returns the exit code, so deferred cleanup runs before exiting
+func run() int {
 	// Enable debug logging in development
 	if os.Getenv("DEBUG") != "" {
 		f, err := tea.LogToFile("debug.log", "debug")
 		if err != nil {
 			fmt.Printf("Could not open debug log: %v\n", err)
-			os.Exit(1)
+			return 1
 		}
 		defer f.Close()
 	}
 
-	p := tea.NewProgram(
-		ui.NewModel(),
-		tea.WithAltScreen(),
-	)
-	
-	if _, err := p.Run(); err != nil {
-		fmt.Printf("Error running Teapot: %v\n", err)
-		os.Exit(1)
-	}
-}
\ No newline at end of file
+	return cli.Run(os.Args[1:], os.Stdout, os.Stderr)
+}